@@ -0,0 +1,18 @@
+# Cheapest available GPU with at least 24GB of GPU memory in eu-west-1
+data "huggingface_compute_catalog" "gpu_24gb" {
+  filter = {
+    vendor            = "aws"
+    region            = "eu-west-1"
+    accelerator       = "gpu"
+    min_gpu_memory_gb = 24
+    available_only    = true
+  }
+}
+
+locals {
+  cheapest_gpu = data.huggingface_compute_catalog.gpu_24gb.computes[0]
+}
+
+output "cheapest_gpu" {
+  value = "${local.cheapest_gpu.instance_type} ${local.cheapest_gpu.instance_size} at ${local.cheapest_gpu.price_per_hour}$/h"
+}
@@ -0,0 +1,8 @@
+data "huggingface_endpoint" "example" {
+  namespace = "<YOUR_NAMESPACE>"
+  name      = "<YOUR_ENDPOINT_NAME>"
+}
+
+output "endpoint_url" {
+  value = data.huggingface_endpoint.example.status.url
+}
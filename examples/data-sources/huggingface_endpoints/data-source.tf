@@ -1,3 +1,17 @@
 data "huggingface_endpoints" "example" {
   namespace = "<YOUR_NAMESPACE>"
-}
\ No newline at end of file
+}
+
+data "huggingface_endpoints" "running_on_aws" {
+  namespace = "<YOUR_NAMESPACE>"
+
+  filter = {
+    tag    = "production"
+    states = ["running"]
+    vendor = "aws"
+  }
+}
+
+output "running_on_aws_ids" {
+  value = data.huggingface_endpoints.running_on_aws.ids
+}
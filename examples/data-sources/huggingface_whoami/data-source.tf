@@ -0,0 +1,10 @@
+data "huggingface_whoami" "current" {}
+
+# Deploy to the first organization of the token owner, or to the owner namespace
+locals {
+  namespace = try(data.huggingface_whoami.current.orgs[0].name, data.huggingface_whoami.current.name)
+}
+
+output "token_role" {
+  value = data.huggingface_whoami.current.token_role
+}
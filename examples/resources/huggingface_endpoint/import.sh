@@ -1,2 +1,5 @@
-# Endpoint can be imported by specifying the numeric identifier.
+# Endpoint can be imported by specifying its namespace and name.
+terraform import huggingface_endpoint.example <namespace>/<endpoint_name>
+
+# A bare endpoint name is resolved against the provider default_namespace.
 terraform import huggingface_endpoint.example <endpoint_name>
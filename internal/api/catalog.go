@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"net/url"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// Availability statuses reported by the catalog.
+const (
+	StatusAvailable   = "available"
+	StatusUnavailable = "unavailable"
+)
+
+// Vendor is a cloud vendor Inference Endpoints can be deployed to.
+type Vendor struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Regions []Region `json:"regions"`
+}
+
+// Region is a region of a cloud vendor.
+type Region struct {
+	Name   string `json:"name"`
+	Label  string `json:"label"`
+	Status string `json:"status"`
+}
+
+// Compute is an instance offered in a region of a cloud vendor.
+type Compute struct {
+	ID              string  `json:"id"`
+	Vendor          string  `json:"vendor"`
+	Region          string  `json:"region"`
+	Accelerator     string  `json:"accelerator"`
+	InstanceType    string  `json:"instanceType"`
+	InstanceSize    string  `json:"instanceSize"`
+	Architecture    string  `json:"architecture"`
+	NumAccelerators int     `json:"numAccelerators"`
+	MemoryGb        int     `json:"memoryGb"`
+	GpuMemoryGb     int     `json:"gpuMemoryGb"`
+	Status          string  `json:"status"`
+	PricePerHour    float64 `json:"pricePerHour"`
+}
+
+// ListVendors returns the cloud vendors and their regions.
+func ListVendors(ctx context.Context, client *huggingface.Client) ([]Vendor, error) {
+	var result struct {
+		Vendors []Vendor `json:"vendors"`
+	}
+
+	if err := getJSON(ctx, client, "/v2/provider", &result); err != nil {
+		return nil, err
+	}
+
+	return result.Vendors, nil
+}
+
+// ListComputes returns the instances offered in a region of a cloud vendor.
+func ListComputes(ctx context.Context, client *huggingface.Client, vendor, region string) ([]Compute, error) {
+	var result struct {
+		Items []Compute `json:"items"`
+	}
+
+	path := "/v2/provider/" + url.PathEscape(vendor) + "/regions/" + url.PathEscape(region) + "/compute"
+	if err := getJSON(ctx, client, path, &result); err != nil {
+		return nil, err
+	}
+
+	// The location is implied by the route when the response omits it
+	for i := range result.Items {
+		if result.Items[i].Vendor == "" {
+			result.Items[i].Vendor = vendor
+		}
+		if result.Items[i].Region == "" {
+			result.Items[i].Region = region
+		}
+	}
+
+	return result.Items, nil
+}
+
+// ListCatalog returns the instances offered across every vendor and region.
+// Non-empty vendor and region arguments restrict the regions queried.
+func ListCatalog(ctx context.Context, client *huggingface.Client, vendor, region string) ([]Compute, error) {
+	vendors, err := ListVendors(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalog []Compute
+	for _, v := range vendors {
+		if vendor != "" && v.Name != vendor {
+			continue
+		}
+
+		for _, r := range v.Regions {
+			if region != "" && r.Name != region {
+				continue
+			}
+
+			computes, err := ListComputes(ctx, client, v.Name, r.Name)
+			if err != nil {
+				return nil, err
+			}
+			catalog = append(catalog, computes...)
+		}
+	}
+
+	return catalog, nil
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"sync"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// CatalogCache lazily loads the whole hardware catalog once and serves it to
+// every caller afterwards. Failed loads are not cached and are retried by the
+// next caller.
+type CatalogCache struct {
+	client *huggingface.Client
+
+	mu      sync.Mutex
+	catalog []Compute
+	loaded  bool
+}
+
+// NewCatalogCache returns an empty cache loading the catalog with the client.
+func NewCatalogCache(client *huggingface.Client) *CatalogCache {
+	return &CatalogCache{client: client}
+}
+
+// Catalog returns the instances offered across every vendor and region.
+func (c *CatalogCache) Catalog(ctx context.Context) ([]Compute, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded {
+		return c.catalog, nil
+	}
+
+	catalog, err := ListCatalog(ctx, c.client, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	c.catalog = catalog
+	c.loaded = true
+
+	return c.catalog, nil
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+func newCatalogServer(t *testing.T) *huggingface.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/provider", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer hf_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"vendors":[
+			{"name":"aws","status":"available","regions":[{"name":"us-east-1","status":"available"},{"name":"eu-west-1","status":"available"}]},
+			{"name":"azure","status":"available","regions":[{"name":"eastus","status":"available"}]}
+		]}`))
+	})
+	mux.HandleFunc("GET /v2/provider/{vendor}/regions/{region}/compute", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"id":"` + r.PathValue("vendor") + `-` + r.PathValue("region") + `-gpu","accelerator":"gpu","gpuMemoryGb":24,"pricePerHour":0.8}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	host, token := server.URL, "hf_test"
+	client, err := huggingface.NewClient(&host, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestListCatalog(t *testing.T) {
+	client := newCatalogServer(t)
+
+	testCases := map[string]struct {
+		vendor      string
+		region      string
+		expectedIDs []string
+	}{
+		"everything": {
+			expectedIDs: []string{"aws-us-east-1-gpu", "aws-eu-west-1-gpu", "azure-eastus-gpu"},
+		},
+		"vendor": {
+			vendor:      "aws",
+			expectedIDs: []string{"aws-us-east-1-gpu", "aws-eu-west-1-gpu"},
+		},
+		"vendor and region": {
+			vendor:      "aws",
+			region:      "eu-west-1",
+			expectedIDs: []string{"aws-eu-west-1-gpu"},
+		},
+		"unknown region": {
+			region: "ap-south-1",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			catalog, err := ListCatalog(context.Background(), client, testCase.vendor, testCase.region)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(catalog) != len(testCase.expectedIDs) {
+				t.Fatalf("expected %d computes, got %+v", len(testCase.expectedIDs), catalog)
+			}
+			for i, compute := range catalog {
+				if compute.ID != testCase.expectedIDs[i] {
+					t.Errorf("expected compute %q, got %q", testCase.expectedIDs[i], compute.ID)
+				}
+				if compute.Vendor == "" || compute.Region == "" {
+					t.Errorf("expected compute %q to be located, got %+v", compute.ID, compute)
+				}
+			}
+		})
+	}
+}
+
+func TestListCatalogUnauthorized(t *testing.T) {
+	client := newCatalogServer(t)
+	client.Token = "hf_other"
+
+	if _, err := ListCatalog(context.Background(), client, "", ""); !IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestCatalogCache(t *testing.T) {
+	var requests int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/provider", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"vendors":[{"name":"aws","regions":[{"name":"us-east-1"}]}]}`))
+	})
+	mux.HandleFunc("GET /v2/provider/{vendor}/regions/{region}/compute", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"id":"aws-us-east-1-cpu"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	host, token := server.URL, "hf_test"
+	client, err := huggingface.NewClient(&host, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewCatalogCache(client)
+
+	if _, err := cache.Catalog(context.Background()); !IsServerError(err) {
+		t.Fatalf("expected the first load to fail, got %v", err)
+	}
+	for range 3 {
+		catalog, err := cache.Catalog(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(catalog) != 1 {
+			t.Fatalf("unexpected catalog: %+v", catalog)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected the catalog to be listed twice, got %d", requests)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"sync"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// EndpointReader shares the result of an in-flight GetEndpoint call with the
+// concurrent callers reading the same endpoint, so a refresh reading an
+// endpoint from a resource and data sources calls the API once. Results are
+// not kept once the call returns, later callers always read a fresh endpoint.
+type EndpointReader struct {
+	client *huggingface.Client
+
+	mu       sync.Mutex
+	inFlight map[string]*endpointCall
+}
+
+type endpointCall struct {
+	done     chan struct{}
+	endpoint *huggingface.EndpointWithStatus
+	err      error
+}
+
+// NewEndpointReader returns a reader getting endpoints with the client.
+func NewEndpointReader(client *huggingface.Client) *EndpointReader {
+	return &EndpointReader{client: client, inFlight: map[string]*endpointCall{}}
+}
+
+// GetEndpoint returns the endpoint namespace/name. The endpoint may be shared
+// with other callers and must not be modified.
+func (r *EndpointReader) GetEndpoint(namespace, name string) (*huggingface.EndpointWithStatus, error) {
+	key := namespace + "/" + name
+
+	r.mu.Lock()
+	if call, ok := r.inFlight[key]; ok {
+		r.mu.Unlock()
+		<-call.done
+		return call.endpoint, call.err
+	}
+
+	call := &endpointCall{done: make(chan struct{})}
+	r.inFlight[key] = call
+	r.mu.Unlock()
+
+	call.endpoint, call.err = r.client.GetEndpoint(namespace, name)
+
+	r.mu.Lock()
+	delete(r.inFlight, key)
+	r.mu.Unlock()
+	close(call.done)
+
+	return call.endpoint, call.err
+}
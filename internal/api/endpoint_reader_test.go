@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+func TestEndpointReader(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`{"name":"demo","status":{"state":"running"}}`))
+	}))
+	defer server.Close()
+
+	token := "hf_test"
+	client, err := huggingface.NewClient(&server.URL, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := NewEndpointReader(client)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			endpoint, err := reader.GetEndpoint("ns", "demo")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if endpoint.Name != "demo" {
+				t.Errorf("expected endpoint demo, got %s", endpoint.Name)
+			}
+		}()
+	}
+
+	// Let every reader join the call in flight before answering it
+	for !readInFlight(reader, "ns/demo") {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 concurrent API call, got %d", got)
+	}
+
+	// Completed calls are not cached
+	if _, err := reader.GetEndpoint("ns", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected a fresh API call once the first one returned, got %d calls", got)
+	}
+}
+
+func readInFlight(reader *EndpointReader, key string) bool {
+	reader.mu.Lock()
+	defer reader.mu.Unlock()
+
+	_, ok := reader.inFlight[key]
+	return ok
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// UpdateEndpoint updates an endpoint like the huggingface client does, but
+// sends the empty model env and secrets maps of update, which the client
+// leaves out, so they can be cleared. Nil maps are still left out.
+func UpdateEndpoint(ctx context.Context, client *huggingface.Client, namespace, name string, update huggingface.EndpointUpdate) (*huggingface.EndpointWithStatus, error) {
+	body, err := marshalEndpointUpdate(update)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint huggingface.EndpointWithStatus
+	endpointURL := client.Host + "/v2/endpoint/" + url.PathEscape(namespace) + "/" + url.PathEscape(name)
+	if err := doJSON(ctx, client, http.MethodPut, endpointURL, body, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+// marshalEndpointUpdate encodes update, keeping its empty model maps.
+func marshalEndpointUpdate(update huggingface.EndpointUpdate) ([]byte, error) {
+	body, err := json.Marshal(update)
+	if err != nil || update.Model == nil {
+		return body, err
+	}
+
+	emptyMaps := map[string]bool{
+		"env":     update.Model.Env != nil && len(update.Model.Env) == 0,
+		"secrets": update.Model.Secrets != nil && len(update.Model.Secrets) == 0,
+	}
+	if !emptyMaps["env"] && !emptyMaps["secrets"] {
+		return body, nil
+	}
+
+	var fields, model map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(fields["model"], &model); err != nil {
+		return nil, err
+	}
+
+	for name, empty := range emptyMaps {
+		if empty {
+			model[name] = json.RawMessage(`{}`)
+		}
+	}
+
+	if fields["model"], err = json.Marshal(model); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
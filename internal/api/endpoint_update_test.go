@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+func TestUpdateEndpoint(t *testing.T) {
+	repository := "openai-community/gpt2"
+	secret := "s3cr3t"
+
+	testCases := map[string]struct {
+		update       huggingface.EndpointUpdate
+		expectedBody string
+	}{
+		"no model": {
+			update:       huggingface.EndpointUpdate{Tags: []string{"gpt2"}},
+			expectedBody: `{"tags":["gpt2"]}`,
+		},
+		"nil maps left out": {
+			update:       huggingface.EndpointUpdate{Model: &huggingface.EndpointModelUpdate{Repository: &repository}},
+			expectedBody: `{"model":{"repository":"openai-community/gpt2"}}`,
+		},
+		"empty maps sent": {
+			update: huggingface.EndpointUpdate{Model: &huggingface.EndpointModelUpdate{
+				Repository: &repository,
+				Env:        map[string]string{},
+				Secrets:    map[string]*string{},
+			}},
+			expectedBody: `{"model":{"env":{},"repository":"openai-community/gpt2","secrets":{}}}`,
+		},
+		"set maps": {
+			update: huggingface.EndpointUpdate{Model: &huggingface.EndpointModelUpdate{
+				Repository: &repository,
+				Env:        map[string]string{},
+				Secrets:    map[string]*string{"API_KEY": &secret},
+			}},
+			expectedBody: `{"model":{"env":{},"repository":"openai-community/gpt2","secrets":{"API_KEY":"s3cr3t"}}}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut || r.URL.Path != "/v2/endpoint/ns/demo" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if r.Header.Get("Authorization") != "Bearer hf_test" {
+					t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				var got, expected any
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatal(err)
+				}
+				_ = json.Unmarshal([]byte(testCase.expectedBody), &expected)
+				gotJSON, _ := json.Marshal(got)
+				expectedJSON, _ := json.Marshal(expected)
+				if string(gotJSON) != string(expectedJSON) {
+					t.Errorf("expected body %s, got %s", expectedJSON, gotJSON)
+				}
+
+				_, _ = w.Write([]byte(`{"name":"demo","status":{"state":"updating"}}`))
+			}))
+			defer server.Close()
+
+			token := "hf_test"
+			client, err := huggingface.NewClient(&server.URL, &token)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			endpoint, err := UpdateEndpoint(context.Background(), client, "ns", "demo", testCase.update)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if endpoint.Name != "demo" || endpoint.Status.State != huggingface.StateUpdating {
+				t.Errorf("unexpected endpoint: %+v", endpoint)
+			}
+		})
+	}
+}
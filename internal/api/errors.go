@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// httpErrorPattern matches the errors built by the huggingface client for
+// HTTP responses with a status code >= 400.
+var httpErrorPattern = regexp.MustCompile(`(?s)^HTTP error (\d{3}): (.*)$`)
+
+// Error is an HTTP error returned by the Inference Endpoints API.
+type Error struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("HTTP error %d: %s", e.StatusCode, e.Body)
+}
+
+// AsError extracts the typed API error from an error returned by the
+// huggingface client. It returns false for transport or decoding errors.
+func AsError(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+
+	matches := httpErrorPattern.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return nil, false
+	}
+
+	statusCode, _ := strconv.Atoi(matches[1])
+	return &Error{StatusCode: statusCode, Body: matches[2]}, true
+}
+
+// StatusCode returns the HTTP status code carried by an error returned by
+// the huggingface client, or 0 when the error did not come from a response.
+func StatusCode(err error) int {
+	apiErr, ok := AsError(err)
+	if !ok {
+		return 0
+	}
+
+	return apiErr.StatusCode
+}
+
+// IsNotFound reports whether the error is a 404 returned by the API.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict reports whether the API refused the request because the
+// resource already exists.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsUnauthorized reports whether the API rejected the token or its scopes.
+func IsUnauthorized(err error) bool {
+	statusCode := StatusCode(err)
+	return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden
+}
+
+// IsServerError reports whether the API failed with a 5xx status code.
+func IsServerError(err error) bool {
+	return StatusCode(err) >= http.StatusInternalServerError
+}
+
+// IsRetryable reports whether the error is transient: a transport error, a
+// rate limit or a 5xx status code.
+func IsRetryable(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || StatusCode(err) == http.StatusTooManyRequests || IsServerError(err)
+}
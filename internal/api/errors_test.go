@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestAsError(t *testing.T) {
+	testCases := map[string]struct {
+		err            error
+		expectedOk     bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		"nil": {
+			err: nil,
+		},
+		"transport error": {
+			err: errors.New("dial tcp: connection refused"),
+		},
+		"client http error": {
+			err:            fmt.Errorf("HTTP error %d: %s", 404, `{"error":"Endpoint not found"}`),
+			expectedOk:     true,
+			expectedStatus: 404,
+			expectedBody:   `{"error":"Endpoint not found"}`,
+		},
+		"multiline body": {
+			err:            fmt.Errorf("HTTP error %d: %s", 502, "bad\ngateway"),
+			expectedOk:     true,
+			expectedStatus: 502,
+			expectedBody:   "bad\ngateway",
+		},
+		"wrapped typed error": {
+			err:            fmt.Errorf("reading endpoint: %w", &Error{StatusCode: 401, Body: "unauthorized"}),
+			expectedOk:     true,
+			expectedStatus: 401,
+			expectedBody:   "unauthorized",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiErr, ok := AsError(testCase.err)
+			if ok != testCase.expectedOk {
+				t.Fatalf("expected ok %t, got %t", testCase.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			if apiErr.StatusCode != testCase.expectedStatus {
+				t.Errorf("expected status %d, got %d", testCase.expectedStatus, apiErr.StatusCode)
+			}
+			if apiErr.Body != testCase.expectedBody {
+				t.Errorf("expected body %q, got %q", testCase.expectedBody, apiErr.Body)
+			}
+		})
+	}
+}
+
+func TestClassification(t *testing.T) {
+	notFound := fmt.Errorf("HTTP error 404: not found")
+	forbidden := fmt.Errorf("HTTP error 403: forbidden")
+	conflict := fmt.Errorf("HTTP error 409: conflict")
+	unavailable := fmt.Errorf("HTTP error 503: unavailable")
+	rateLimited := fmt.Errorf("HTTP error 429: too many requests")
+	transport := &url.Error{Op: "Get", URL: "https://api.endpoints.huggingface.cloud", Err: errors.New("connection reset by peer")}
+
+	if !IsNotFound(notFound) || IsNotFound(forbidden) || IsNotFound(nil) {
+		t.Error("unexpected IsNotFound classification")
+	}
+	if !IsConflict(conflict) || IsConflict(notFound) {
+		t.Error("unexpected IsConflict classification")
+	}
+	if !IsUnauthorized(forbidden) || IsUnauthorized(notFound) {
+		t.Error("unexpected IsUnauthorized classification")
+	}
+	if !IsServerError(unavailable) || IsServerError(notFound) {
+		t.Error("unexpected IsServerError classification")
+	}
+	if !IsRetryable(unavailable) || !IsRetryable(rateLimited) || !IsRetryable(transport) || IsRetryable(forbidden) || IsRetryable(nil) {
+		t.Error("unexpected IsRetryable classification")
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"io"
+	"net/http"
+)
+
+// LimitTransport bounds the number of requests in flight at once across every
+// resource and data source sharing the client. A slot is held until the
+// response body is closed, and is not held while a RetryTransport wrapping it
+// waits between attempts.
+type LimitTransport struct {
+	base  http.RoundTripper
+	slots chan struct{}
+}
+
+// NewLimitTransport wraps base, http.DefaultTransport when nil, allowing at
+// most limit concurrent requests.
+func NewLimitTransport(base http.RoundTripper, limit int) *LimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &LimitTransport{base: base, slots: make(chan struct{}, limit)}
+}
+
+func (t *LimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+func (t *LimitTransport) release() {
+	<-t.slots
+}
+
+// releaseOnClose frees the slot of a request once its body is closed, at
+// most once.
+type releaseOnClose struct {
+	io.ReadCloser
+	release  func()
+	released bool
+}
+
+func (r *releaseOnClose) Close() error {
+	if !r.released {
+		r.released = true
+		defer r.release()
+	}
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitTransport(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewLimitTransport(nil, 2)}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", got)
+	}
+}
+
+func TestLimitTransportQueueNotTimed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(150 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	// Same layering as the provider: the attempt timeout starts once the
+	// limiter gives a slot to the attempt
+	transport := NewRetryTransport(
+		context.Background(),
+		NewLimitTransport(NewTimeoutTransport(nil, 200*time.Millisecond), 1),
+		RetryConfig{MaxRetries: 2, MinWait: time.Second, MaxWait: time.Minute},
+	)
+	transport.sleep = func(context.Context, time.Duration) error {
+		t.Error("expected no retry")
+		return nil
+	}
+	client := &http.Client{Transport: transport}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := client.Get(server.URL + "/v2/endpoint/ns/demo")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+		// Queue the reads in order
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The creation waits for the reads far longer than the attempt timeout
+	// and is not retried, so it must get the whole timeout once it runs
+	start := time.Now()
+	resp, err := client.Post(server.URL+"/v2/endpoint/ns", "application/json", strings.NewReader(`{"name":"demo"}`))
+	if err != nil {
+		t.Fatalf("expected the queued creation to succeed, got: %s", err)
+	}
+	resp.Body.Close()
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected the creation to wait for the reads, waited %s", elapsed)
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTimeoutTransport(nil, 50*time.Millisecond)}
+
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the request to time out")
+	}
+	if !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("expected a deadline exceeded error, got: %s", err)
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem API calls are logged under. Its level
+// is set with the TF_LOG_PROVIDER_HUGGINGFACE_API environment variable and
+// defaults to the provider level.
+const LogSubsystem = "api"
+
+// NewLoggingContext sets up the api logging subsystem and masks the token in
+// every log written with the returned context, by the provider and the
+// subsystem alike.
+func NewLoggingContext(ctx context.Context, token string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_HUGGINGFACE", LogSubsystem))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, "authorization")
+
+	if token != "" {
+		ctx = tflog.MaskLogStrings(ctx, token)
+		ctx = tflog.SubsystemMaskLogStrings(ctx, LogSubsystem, token)
+	}
+
+	return ctx
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// getJSON performs an authenticated GET request against the API host of the
+// huggingface client and decodes the JSON response into out. It covers the
+// API routes the client does not expose, with the same authentication.
+func getJSON(ctx context.Context, client *huggingface.Client, path string, out any) error {
+	return getJSONURL(ctx, client, client.Host+path, out)
+}
+
+// getJSONURL is getJSON for APIs hosted elsewhere, such as the Hub.
+func getJSONURL(ctx context.Context, client *huggingface.Client, url string, out any) error {
+	return doJSON(ctx, client, http.MethodGet, url, nil, out)
+}
+
+// doJSON performs an authenticated request with the JSON body, if any, and
+// decodes the JSON response into out.
+func doJSON(ctx context.Context, client *huggingface.Client, method, url string, body []byte, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return err
+	}
+
+	if client.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+client.Token)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := client.Client
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return &Error{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Retry defaults used when the provider configuration leaves them unset.
+const (
+	DefaultMaxRetries   = 4
+	DefaultRetryMinWait = 1 * time.Second
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// idempotentActions are the POST endpoint actions that can be repeated
+// without side effects, unlike the creation of an endpoint.
+var idempotentActions = []string{"/pause", "/resume", "/scale-to-zero"}
+
+// RetryConfig configures the retries of a RetryTransport.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// MinWait and MaxWait bound the exponential backoff between attempts.
+	// A Retry-After header sent by the API takes precedence over them, still
+	// capped by MaxWait.
+	MinWait time.Duration
+	MaxWait time.Duration
+}
+
+// RetryTransport retries the requests failing with a transient error.
+//
+// Rate limited requests (429) are rejected before being processed and are
+// retried whatever their method. Network errors and 502, 503 and 504
+// responses are only retried for idempotent requests, so a POST creating an
+// endpoint is never sent twice once it may have reached the API.
+type RetryTransport struct {
+	// ctx carries the logger, as the huggingface client does not propagate
+	// contexts to its requests.
+	ctx    context.Context
+	base   http.RoundTripper
+	config RetryConfig
+
+	// sleep waits between attempts, replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryTransport wraps base, http.DefaultTransport when nil, and logs the
+// retries with the logger of ctx.
+func NewRetryTransport(ctx context.Context, base http.RoundTripper, config RetryConfig) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &RetryTransport{ctx: ctx, base: base, config: config, sleep: sleepContext}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := t.prepareAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+
+		if attempt >= t.config.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.SubsystemWarn(t.ctx, LogSubsystem, "Retrying Inference Endpoints API request", fields)
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// prepareAttempt clones the request with a fresh body.
+func (t *RetryTransport) prepareAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	attemptReq := req.Clone(req.Context())
+	if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attemptReq.Body = body
+	}
+
+	return attemptReq, nil
+}
+
+// shouldRetry applies the retry rules documented on RetryTransport.
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	// The request was cancelled or timed out as a whole
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// The body cannot be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the next attempt, from the Retry-After
+// header when present or an exponential backoff with jitter otherwise, never
+// longer than MaxWait.
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(wait, t.config.MaxWait)
+		}
+	}
+
+	wait := t.config.MaxWait
+	if attempt < 32 {
+		wait = min(t.config.MinWait<<attempt, t.config.MaxWait)
+	}
+
+	// Spread the retries of concurrent requests over the second half of the wait
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int64N(half+1))
+	}
+
+	return wait
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		for _, action := range idempotentActions {
+			if strings.HasSuffix(req.URL.Path, action) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	testCases := map[string]struct {
+		method           string
+		path             string
+		statuses         []int
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		"get retried on 503": {
+			method:           http.MethodGet,
+			path:             "/v2/endpoint/ns/demo",
+			statuses:         []int{503, 502, 200},
+			expectedStatus:   200,
+			expectedAttempts: 3,
+		},
+		"create retried on 429": {
+			method:           http.MethodPost,
+			path:             "/v2/endpoint/ns",
+			statuses:         []int{429, 200},
+			expectedStatus:   200,
+			expectedAttempts: 2,
+		},
+		"create not retried on 503": {
+			method:           http.MethodPost,
+			path:             "/v2/endpoint/ns",
+			statuses:         []int{503, 200},
+			expectedStatus:   503,
+			expectedAttempts: 1,
+		},
+		"pause retried on 503": {
+			method:           http.MethodPost,
+			path:             "/v2/endpoint/ns/demo/pause",
+			statuses:         []int{503, 200},
+			expectedStatus:   200,
+			expectedAttempts: 2,
+		},
+		"client error not retried": {
+			method:           http.MethodGet,
+			path:             "/v2/endpoint/ns/demo",
+			statuses:         []int{404, 200},
+			expectedStatus:   404,
+			expectedAttempts: 1,
+		},
+		"retries exhausted": {
+			method:           http.MethodDelete,
+			path:             "/v2/endpoint/ns/demo",
+			statuses:         []int{503, 503, 503, 503},
+			expectedStatus:   503,
+			expectedAttempts: 3,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+				if r.Method == http.MethodPost {
+					body, _ := io.ReadAll(r.Body)
+					if string(body) != `{"name":"demo"}` {
+						t.Errorf("attempt %d: unexpected body %q", attempt, body)
+					}
+				}
+				w.WriteHeader(testCase.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			transport := NewRetryTransport(context.Background(), nil, RetryConfig{MaxRetries: 2, MinWait: time.Second, MaxWait: time.Minute})
+			transport.sleep = func(context.Context, time.Duration) error { return nil }
+
+			var body io.Reader
+			if testCase.method == http.MethodPost {
+				body = strings.NewReader(`{"name":"demo"}`)
+			}
+			req, err := http.NewRequest(testCase.method, server.URL+testCase.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := (&http.Client{Transport: transport}).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != testCase.expectedStatus {
+				t.Errorf("expected status %d, got %d", testCase.expectedStatus, resp.StatusCode)
+			}
+			if got := attempts.Load(); got != testCase.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", testCase.expectedAttempts, got)
+			}
+		})
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := NewRetryTransport(context.Background(), nil, RetryConfig{MinWait: time.Second, MaxWait: 10 * time.Second})
+
+	for attempt, expectedMax := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		wait := transport.backoff(attempt, nil)
+		if wait < expectedMax/2 || wait > expectedMax {
+			t.Errorf("attempt %d: expected a wait between %s and %s, got %s", attempt, expectedMax/2, expectedMax, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if wait := transport.backoff(0, resp); wait != 7*time.Second {
+		t.Errorf("expected the Retry-After wait of 7s, got %s", wait)
+	}
+
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	if wait := transport.backoff(0, resp); wait != 10*time.Second {
+		t.Errorf("expected the Retry-After wait to be capped at 10s, got %s", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		value        string
+		expectedWait time.Duration
+		expectedOk   bool
+	}{
+		"empty": {},
+		"seconds": {
+			value:        "5",
+			expectedWait: 5 * time.Second,
+			expectedOk:   true,
+		},
+		"negative seconds": {
+			value:      "-5",
+			expectedOk: true,
+		},
+		"http date": {
+			value:        "Mon, 01 Jan 2024 12:00:30 GMT",
+			expectedWait: 30 * time.Second,
+			expectedOk:   true,
+		},
+		"past http date": {
+			value:      "Mon, 01 Jan 2024 11:00:00 GMT",
+			expectedOk: true,
+		},
+		"invalid": {
+			value: "soon",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(testCase.value, now)
+			if ok != testCase.expectedOk || wait != testCase.expectedWait {
+				t.Errorf("expected (%s, %t), got (%s, %t)", testCase.expectedWait, testCase.expectedOk, wait, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// DefaultRevision is the branch served when a model revision is not set.
+const DefaultRevision = "main"
+
+// commitSHAPattern matches a full commit SHA, which needs no resolution.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// IsCommitSHA reports whether the revision is a full commit SHA.
+func IsCommitSHA(revision string) bool {
+	return commitSHAPattern.MatchString(revision)
+}
+
+// ResolveRevision returns the commit SHA a branch, tag or commit of a model
+// repository points to on the Hub.
+func ResolveRevision(ctx context.Context, client *huggingface.Client, hubURL, repository, revision string) (string, error) {
+	if IsCommitSHA(revision) {
+		return revision, nil
+	}
+
+	var result struct {
+		SHA string `json:"sha"`
+	}
+
+	// The repository keeps its slash, the revision may contain one too
+	endpoint := strings.TrimSuffix(hubURL, "/") + "/api/models/" + repository + "/revision/" + url.PathEscape(revision)
+	if err := getJSONURL(ctx, client, endpoint, &result); err != nil {
+		return "", err
+	}
+
+	return result.SHA, nil
+}
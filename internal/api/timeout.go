@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// TimeoutTransport bounds every request it sends, until its response body is
+// closed. Wrapped by a RetryTransport, it gives each attempt its own timeout,
+// as the timeout of the http.Client would otherwise be shared by all of them.
+// Wrapping a LimitTransport with it instead would count the wait for a slot
+// against the timeout, so it goes below the limiter.
+type TimeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+// NewTimeoutTransport wraps base, http.DefaultTransport when nil, bounding
+// every request by timeout.
+func NewTimeoutTransport(base http.RoundTripper, timeout time.Duration) *TimeoutTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &TimeoutTransport{base: base, timeout: timeout}
+}
+
+func (t *TimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnClose releases the timeout of a request once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxTracedBodySize caps the size of the bodies written to the logs.
+const maxTracedBodySize = 4096
+
+// redacted replaces sensitive values in traced bodies.
+const redacted = "***"
+
+// sensitiveKeys are the suffixes of the JSON keys whose values are never
+// logged, matched case-insensitively so hfToken is redacted but not
+// maxTotalTokens. Every value of a secrets map is redacted.
+var sensitiveKeys = []string{"password", "token", "secret", "authorization", "apikey", "api_key"}
+
+// TracingTransport logs every request with its method, URL, status, latency
+// and redacted bodies in the api subsystem.
+type TracingTransport struct {
+	// ctx carries the logger, as the huggingface client does not propagate
+	// contexts to its requests.
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+// NewTracingTransport wraps base, http.DefaultTransport when nil, and logs
+// with the logger of ctx, typically set up with NewLoggingContext.
+func NewTracingTransport(ctx context.Context, base http.RoundTripper) *TracingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &TracingTransport{ctx: ctx, base: base}
+}
+
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]any{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	}
+
+	// The request of the caller is left untouched, a clone carrying the read
+	// body being sent instead
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		fields["request_body"] = redactBody(body)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(t.ctx, LogSubsystem, "Inference Endpoints API request failed", fields)
+		return nil, err
+	}
+
+	fields["status"] = resp.StatusCode
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	fields["response_body"] = redactBody(body)
+
+	tflog.SubsystemDebug(t.ctx, LogSubsystem, "Inference Endpoints API request", fields)
+
+	return resp, nil
+}
+
+// readRequestBody reads the body of req through GetBody when set, so the body
+// itself can still be sent again, and closes the body as RoundTrip must.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		defer req.Body.Close()
+		return io.ReadAll(req.Body)
+	}
+
+	req.Body.Close()
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// redactBody returns a loggable version of a body, with the values of
+// sensitive JSON keys redacted and the output truncated.
+func redactBody(body []byte) string {
+	var content any
+	if err := json.Unmarshal(body, &content); err == nil {
+		if redactedBody, err := json.Marshal(redactValue(content)); err == nil {
+			body = redactedBody
+		}
+	}
+
+	if len(body) > maxTracedBodySize {
+		return string(body[:maxTracedBodySize]) + "...(truncated)"
+	}
+
+	return string(body)
+}
+
+func redactValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			switch {
+			case strings.EqualFold(key, "secrets"):
+				value[key] = redactAll(nested)
+			case isSensitiveKey(key):
+				value[key] = redacted
+			default:
+				value[key] = redactValue(nested)
+			}
+		}
+	case []any:
+		for i, nested := range value {
+			value[i] = redactValue(nested)
+		}
+	}
+
+	return value
+}
+
+// redactAll redacts every value of a secrets map, keeping its keys.
+func redactAll(value any) any {
+	secrets, ok := value.(map[string]any)
+	if !ok {
+		return redacted
+	}
+
+	for key := range secrets {
+		secrets[key] = redacted
+	}
+
+	return secrets
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.HasSuffix(key, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
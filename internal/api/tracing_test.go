@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestTracingTransport(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_HUGGINGFACE_API", "DEBUG")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "s3cr3t") {
+			t.Errorf("request body was not restored: %s", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"name":"demo","model":{"image":{"tgi":{"maxTotalTokens":2048}},"secrets":{"DB_URL":"postgres://x"}}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := NewLoggingContext(tflogtest.RootLogger(context.Background(), &output), "hf_test")
+
+	client := &http.Client{Transport: NewTracingTransport(ctx, nil)}
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/v2/endpoint/ns", strings.NewReader(
+		`{"name":"demo","model":{"image":{"custom":{"credentials":{"username":"u","password":"s3cr3t"}}}},"hfToken":"hf_test"}`,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer hf_test")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "postgres://x") {
+		t.Errorf("response body was not restored: %s", body)
+	}
+
+	logs := output.String()
+	for _, expected := range []string{`"method":"POST"`, `/v2/endpoint/ns"`, `"status":201`, "latency_ms", "maxTotalTokens", `\"DB_URL\":\"***\"`} {
+		if !strings.Contains(logs, expected) {
+			t.Errorf("expected %s in logs, got: %s", expected, logs)
+		}
+	}
+	for _, leaked := range []string{"s3cr3t", "hf_test", "postgres://x"} {
+		if strings.Contains(logs, leaked) {
+			t.Errorf("%s leaked in logs: %s", leaked, logs)
+		}
+	}
+}
+
+func TestTracingTransportRequestUntouched(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"demo"}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"demo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := req.Body
+
+	resp, err := NewTracingTransport(context.Background(), nil).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if req.Body != body {
+		t.Error("expected the body of the request to be left untouched")
+	}
+
+	// A retry sends the body again from GetBody
+	retried, err := req.GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := io.ReadAll(retried); string(content) != `{"name":"demo"}` {
+		t.Errorf("expected GetBody to return the whole body, got %s", content)
+	}
+}
+
+func TestTracingTransportLevel(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_HUGGINGFACE_API", "WARN")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := NewLoggingContext(tflogtest.RootLogger(context.Background(), &output), "hf_test")
+
+	client := &http.Client{Transport: NewTracingTransport(ctx, nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if output.Len() != 0 {
+		t.Errorf("expected no debug logs with TF_LOG_PROVIDER_HUGGINGFACE_API=WARN, got: %s", output.String())
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"not json": {
+			body:     "Bad Gateway",
+			expected: "Bad Gateway",
+		},
+		"nested keys": {
+			body:     `{"items":[{"apiKey":"a","name":"b"}],"Authorization":"c"}`,
+			expected: `{"Authorization":"***","items":[{"apiKey":"***","name":"b"}]}`,
+		},
+		"token suffix only": {
+			body:     `{"hfToken":"a","maxInputTokens":1}`,
+			expected: `{"hfToken":"***","maxInputTokens":1}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := redactBody([]byte(test.body)); actual != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+
+	if actual := redactBody(bytes.Repeat([]byte("a"), maxTracedBodySize+1)); !strings.HasSuffix(actual, "...(truncated)") {
+		t.Errorf("expected a truncated body, got %d bytes", len(actual))
+	}
+}
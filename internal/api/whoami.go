@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// DefaultHubURL is the Hugging Face Hub serving the whoami API.
+const DefaultHubURL = "https://huggingface.co"
+
+// Token roles reported by the whoami API.
+const (
+	TokenRoleRead        = "read"
+	TokenRoleWrite       = "write"
+	TokenRoleFineGrained = "fineGrained"
+)
+
+// endpointsPermissionPrefix prefixes the fine-grained permissions on
+// Inference Endpoints, such as inference.endpoints.write.
+const endpointsPermissionPrefix = "inference.endpoints."
+
+// WhoAmI is the owner of a token and the token permissions.
+type WhoAmI struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Fullname string `json:"fullname"`
+	Orgs     []Org  `json:"orgs"`
+	Auth     Auth   `json:"auth"`
+}
+
+// Auth describes how the request was authenticated.
+type Auth struct {
+	Type        string       `json:"type"`
+	AccessToken *AccessToken `json:"accessToken"`
+}
+
+// Org is an organization the token owner belongs to.
+type Org struct {
+	Name      string `json:"name"`
+	RoleInOrg string `json:"roleInOrg"`
+}
+
+// AccessToken describes the token used to authenticate.
+type AccessToken struct {
+	DisplayName string       `json:"displayName"`
+	Role        string       `json:"role"`
+	FineGrained *FineGrained `json:"fineGrained"`
+}
+
+// FineGrained lists the permissions of a fine-grained token.
+type FineGrained struct {
+	Global []string     `json:"global"`
+	Scoped []TokenScope `json:"scoped"`
+}
+
+// TokenScope lists the permissions of a fine-grained token on a user or an
+// organization.
+type TokenScope struct {
+	Entity      TokenEntity `json:"entity"`
+	Permissions []string    `json:"permissions"`
+}
+
+// TokenEntity is the user or organization a token scope applies to.
+type TokenEntity struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// GetWhoAmI returns the owner of the token of the client from the Hub.
+func GetWhoAmI(ctx context.Context, client *huggingface.Client, hubURL string) (*WhoAmI, error) {
+	var whoami WhoAmI
+	if err := getJSONURL(ctx, client, strings.TrimSuffix(hubURL, "/")+"/api/whoami-v2", &whoami); err != nil {
+		return nil, err
+	}
+
+	return &whoami, nil
+}
+
+// Role returns the role of the token, empty when the Hub did not report it.
+func (w *WhoAmI) Role() string {
+	if w.Auth.AccessToken == nil {
+		return ""
+	}
+
+	return w.Auth.AccessToken.Role
+}
+
+// HasEndpointsAccess reports whether a fine-grained token holds any
+// permission on Inference Endpoints. Other tokens always have access, read
+// tokens being limited to reads.
+func (w *WhoAmI) HasEndpointsAccess() bool {
+	if w.Role() != TokenRoleFineGrained || w.Auth.AccessToken.FineGrained == nil {
+		return true
+	}
+
+	fineGrained := w.Auth.AccessToken.FineGrained
+	permissions := fineGrained.Global
+	for _, scope := range fineGrained.Scoped {
+		permissions = append(permissions, scope.Permissions...)
+	}
+
+	for _, permission := range permissions {
+		if strings.HasPrefix(permission, endpointsPermissionPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WhoAmICache loads the owner of the token once, when validating the token
+// at configure time or reading the whoami data source. Failed loads are not
+// cached and are retried by the next caller.
+type WhoAmICache struct {
+	client *huggingface.Client
+	hubURL string
+
+	mu     sync.Mutex
+	whoami *WhoAmI
+}
+
+// NewWhoAmICache returns an empty cache querying the Hub at hubURL.
+func NewWhoAmICache(client *huggingface.Client, hubURL string) *WhoAmICache {
+	return &WhoAmICache{client: client, hubURL: hubURL}
+}
+
+// WhoAmI returns the owner of the token.
+func (c *WhoAmICache) WhoAmI(ctx context.Context) (*WhoAmI, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.whoami != nil {
+		return c.whoami, nil
+	}
+
+	whoami, err := GetWhoAmI(ctx, c.client, c.hubURL)
+	if err != nil {
+		return nil, err
+	}
+	c.whoami = whoami
+
+	return c.whoami, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import "testing"
+
+func TestWhoAmIHasEndpointsAccess(t *testing.T) {
+	testCases := map[string]struct {
+		accessToken *AccessToken
+		expected    bool
+	}{
+		"no token details": {
+			expected: true,
+		},
+		"write token": {
+			accessToken: &AccessToken{Role: TokenRoleWrite},
+			expected:    true,
+		},
+		"read token": {
+			accessToken: &AccessToken{Role: TokenRoleRead},
+			expected:    true,
+		},
+		"fine-grained global permission": {
+			accessToken: &AccessToken{Role: TokenRoleFineGrained, FineGrained: &FineGrained{
+				Global: []string{"inference.endpoints.write"},
+			}},
+			expected: true,
+		},
+		"fine-grained scoped permission": {
+			accessToken: &AccessToken{Role: TokenRoleFineGrained, FineGrained: &FineGrained{
+				Scoped: []TokenScope{{Entity: TokenEntity{Type: "org", Name: "acme"}, Permissions: []string{"inference.endpoints.infer.write"}}},
+			}},
+			expected: true,
+		},
+		"fine-grained without endpoints permission": {
+			accessToken: &AccessToken{Role: TokenRoleFineGrained, FineGrained: &FineGrained{
+				Global: []string{"inference.serverless.write"},
+				Scoped: []TokenScope{{Entity: TokenEntity{Type: "user", Name: "me"}, Permissions: []string{"repo.content.read"}}},
+			}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			whoami := &WhoAmI{Auth: Auth{AccessToken: testCase.accessToken}}
+			if got := whoami.HasEndpointsAccess(); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
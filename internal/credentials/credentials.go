@@ -0,0 +1,83 @@
+// Package credentials resolves the Hugging Face token from the provider
+// configuration, the environment or the files written by huggingface-cli.
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sources of the resolved token, reported in debug logs and diagnostics.
+const (
+	SourceConfig    = "config"
+	SourceEnv       = "HF_TOKEN"
+	SourceTokenPath = "HF_TOKEN_PATH"
+	SourceHFHome    = "HF_HOME"
+	SourceCLICache  = "huggingface-cli cache"
+)
+
+// Resolve returns the token and where it was found, looking in order at:
+// the configured token, the HF_TOKEN environment variable, the file named by
+// HF_TOKEN_PATH, $HF_HOME/token and ~/.cache/huggingface/token. Like
+// huggingface_hub, the default cache is not looked at when HF_HOME is set. An
+// empty token is returned when none of them is set.
+func Resolve(configToken string) (token, source string, err error) {
+	if configToken != "" {
+		return configToken, SourceConfig, nil
+	}
+
+	if token := os.Getenv("HF_TOKEN"); token != "" {
+		return token, SourceEnv, nil
+	}
+
+	// An explicit token path must be readable
+	if tokenPath := os.Getenv("HF_TOKEN_PATH"); tokenPath != "" {
+		token, err := readToken(tokenPath)
+		if err != nil {
+			return "", "", fmt.Errorf("reading HF_TOKEN_PATH: %w", err)
+		}
+		return token, SourceTokenPath, nil
+	}
+
+	if hfHome := os.Getenv("HF_HOME"); hfHome != "" {
+		token, err := readOptionalToken(filepath.Join(hfHome, "token"))
+		if err != nil || token != "" {
+			return token, SourceHFHome, err
+		}
+		return "", "", nil
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		token, err := readOptionalToken(filepath.Join(home, ".cache", "huggingface", "token"))
+		if err != nil || token != "" {
+			return token, SourceCLICache, err
+		}
+	}
+
+	return "", "", nil
+}
+
+// readToken reads a token file, ignoring surrounding whitespace.
+func readToken(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// readOptionalToken reads a token file, returning an empty token when the
+// file does not exist.
+func readOptionalToken(name string) (string, error) {
+	token, err := readToken(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+
+	return token, err
+}
@@ -0,0 +1,100 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeToken(t *testing.T, name, token string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(token), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	testCases := map[string]struct {
+		configToken    string
+		env            map[string]string
+		files          map[string]string
+		expectedToken  string
+		expectedSource string
+		expectedErr    bool
+	}{
+		"nothing": {},
+		"config": {
+			configToken:    "hf_config",
+			env:            map[string]string{"HF_TOKEN": "hf_env"},
+			expectedToken:  "hf_config",
+			expectedSource: SourceConfig,
+		},
+		"env": {
+			env:            map[string]string{"HF_TOKEN": "hf_env", "HF_TOKEN_PATH": "token_path"},
+			files:          map[string]string{"token_path": "hf_token_path"},
+			expectedToken:  "hf_env",
+			expectedSource: SourceEnv,
+		},
+		"token path": {
+			env:            map[string]string{"HF_TOKEN_PATH": "token_path", "HF_HOME": "hf_home"},
+			files:          map[string]string{"token_path": "hf_token_path\n", "hf_home/token": "hf_home"},
+			expectedToken:  "hf_token_path",
+			expectedSource: SourceTokenPath,
+		},
+		"missing token path": {
+			env:         map[string]string{"HF_TOKEN_PATH": "missing"},
+			files:       map[string]string{"home/.cache/huggingface/token": "hf_cli"},
+			expectedErr: true,
+		},
+		"hf home": {
+			env:            map[string]string{"HF_HOME": "hf_home"},
+			files:          map[string]string{"hf_home/token": "  hf_home  ", "home/.cache/huggingface/token": "hf_cli"},
+			expectedToken:  "hf_home",
+			expectedSource: SourceHFHome,
+		},
+		"hf home without token": {
+			env:   map[string]string{"HF_HOME": "hf_home"},
+			files: map[string]string{"home/.cache/huggingface/token": "hf_cli"},
+		},
+		"cli cache": {
+			files:          map[string]string{"home/.cache/huggingface/token": "hf_cli\n"},
+			expectedToken:  "hf_cli",
+			expectedSource: SourceCLICache,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			t.Setenv("HOME", filepath.Join(dir, "home"))
+			for _, key := range []string{"HF_TOKEN", "HF_TOKEN_PATH", "HF_HOME"} {
+				t.Setenv(key, "")
+			}
+			for key, value := range testCase.env {
+				if key != "HF_TOKEN" {
+					value = filepath.Join(dir, value)
+				}
+				t.Setenv(key, value)
+			}
+			for name, token := range testCase.files {
+				writeToken(t, filepath.Join(dir, name), token)
+			}
+
+			token, source, err := Resolve(testCase.configToken)
+			if (err != nil) != testCase.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != testCase.expectedToken {
+				t.Errorf("expected token %q, got %q", testCase.expectedToken, token)
+			}
+			if source != testCase.expectedSource {
+				t.Errorf("expected source %q, got %q", testCase.expectedSource, source)
+			}
+		})
+	}
+}
@@ -95,20 +95,34 @@ func (e EndpointComputeScalingMeasure) AttributeTypes() map[string]attr.Type {
 }
 
 type Model struct {
-	Repository types.String `tfsdk:"repository"`
-	Framework  types.String `tfsdk:"framework"`
-	Task       types.String `tfsdk:"task"`
-	Image      types.Object `tfsdk:"image"`
+	Repository       types.String `tfsdk:"repository"`
+	Revision         types.String `tfsdk:"revision"`
+	ResolvedSHA      types.String `tfsdk:"resolved_sha"`
+	TrackRevision    types.Bool   `tfsdk:"track_revision"`
+	Framework        types.String `tfsdk:"framework"`
+	Task             types.String `tfsdk:"task"`
+	Image            types.Object `tfsdk:"image"`
+	Env              types.Map    `tfsdk:"env"`
+	Secrets          types.Map    `tfsdk:"secrets"`
+	SecretsWO        types.Map    `tfsdk:"secrets_wo"`
+	SecretsWOVersion types.Int32  `tfsdk:"secrets_wo_version"`
 }
 
 func (m Model) AttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"repository": types.StringType,
-		"framework":  types.StringType,
-		"task":       types.StringType,
+		"repository":     types.StringType,
+		"revision":       types.StringType,
+		"resolved_sha":   types.StringType,
+		"track_revision": types.BoolType,
+		"framework":      types.StringType,
+		"task":           types.StringType,
 		"image": types.ObjectType{
 			AttrTypes: ModelImage{}.AttributeTypes(),
 		},
+		"env":                types.MapType{ElemType: types.StringType},
+		"secrets":            types.MapType{ElemType: types.StringType},
+		"secrets_wo":         types.MapType{ElemType: types.StringType},
+		"secrets_wo_version": types.Int32Type,
 	}
 }
 
@@ -287,14 +301,90 @@ func (m ModelImageCustom) AttributeTypes() map[string]attr.Type {
 }
 
 type Credentials struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int32  `tfsdk:"password_wo_version"`
 }
 
 func (m Credentials) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"username":            types.StringType,
+		"password":            types.StringType,
+		"password_wo":         types.StringType,
+		"password_wo_version": types.Int32Type,
+	}
+}
+
+// DataSourceModel is the model of an endpoint read by the data sources,
+// without the attributes only the endpoint resource manages.
+type DataSourceModel struct {
+	Repository  types.String `tfsdk:"repository"`
+	Revision    types.String `tfsdk:"revision"`
+	ResolvedSHA types.String `tfsdk:"resolved_sha"`
+	Framework   types.String `tfsdk:"framework"`
+	Task        types.String `tfsdk:"task"`
+	Image       types.Object `tfsdk:"image"`
+	Env         types.Map    `tfsdk:"env"`
+}
+
+func (m DataSourceModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"repository":   types.StringType,
+		"revision":     types.StringType,
+		"resolved_sha": types.StringType,
+		"framework":    types.StringType,
+		"task":         types.StringType,
+		"image": types.ObjectType{
+			AttrTypes: DataSourceModelImage{}.AttributeTypes(),
+		},
+		"env": types.MapType{ElemType: types.StringType},
+	}
+}
+
+type DataSourceModelImage struct {
+	HuggingFace       types.Object `tfsdk:"huggingface"`
+	HuggingFaceNeuron types.Object `tfsdk:"huggingface_neuron"`
+	TGI               types.Object `tfsdk:"tgi"`
+	TGINeuron         types.Object `tfsdk:"tgi_neuron"`
+	TEI               types.Object `tfsdk:"tei"`
+	LlamaCpp          types.Object `tfsdk:"llamacpp"`
+	Custom            types.Object `tfsdk:"custom"`
+}
+
+func (m DataSourceModelImage) AttributeTypes() map[string]attr.Type {
+	attributeTypes := ModelImage{}.AttributeTypes()
+	attributeTypes["custom"] = types.ObjectType{
+		AttrTypes: DataSourceModelImageCustom{}.AttributeTypes(),
+	}
+	return attributeTypes
+}
+
+type DataSourceModelImageCustom struct {
+	HealthRoute types.String `tfsdk:"health_route"`
+	Port        types.Int32  `tfsdk:"port"`
+	URL         types.String `tfsdk:"url"`
+	Credentials types.Object `tfsdk:"credentials"`
+}
+
+func (m DataSourceModelImageCustom) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"health_route": types.StringType,
+		"port":         types.Int32Type,
+		"url":          types.StringType,
+		"credentials": types.ObjectType{
+			AttrTypes: DataSourceCredentials{}.AttributeTypes(),
+		},
+	}
+}
+
+type DataSourceCredentials struct {
+	Username types.String `tfsdk:"username"`
+}
+
+func (m DataSourceCredentials) AttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"username": types.StringType,
-		"password": types.StringType,
 	}
 }
 
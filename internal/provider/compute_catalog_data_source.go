@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &computeCatalogDataSource{}
+	_ datasource.DataSourceWithConfigure = &computeCatalogDataSource{}
+)
+
+func NewComputeCatalogDataSource() datasource.DataSource {
+	return &computeCatalogDataSource{}
+}
+
+type computeCatalogDataSource struct {
+	client *huggingface.Client
+}
+
+func (d *computeCatalogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compute_catalog"
+}
+
+func (d *computeCatalogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the instances Inference Endpoints can be deployed to, sorted by ascending hourly price.",
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"vendor": schema.StringAttribute{
+						Optional: true,
+					},
+					"region": schema.StringAttribute{
+						Optional: true,
+					},
+					"accelerator": schema.StringAttribute{
+						Optional: true,
+					},
+					"instance_type": schema.StringAttribute{
+						Optional: true,
+					},
+					"min_accelerators": schema.Int32Attribute{
+						Optional: true,
+					},
+					"min_memory_gb": schema.Int32Attribute{
+						Optional: true,
+					},
+					"min_gpu_memory_gb": schema.Int32Attribute{
+						Optional: true,
+					},
+					"max_price_per_hour": schema.Float64Attribute{
+						Optional: true,
+					},
+					"available_only": schema.BoolAttribute{
+						Optional: true,
+					},
+				},
+			},
+			"computes": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"vendor": schema.StringAttribute{
+							Computed: true,
+						},
+						"region": schema.StringAttribute{
+							Computed: true,
+						},
+						"accelerator": schema.StringAttribute{
+							Computed: true,
+						},
+						"instance_type": schema.StringAttribute{
+							Computed: true,
+						},
+						"instance_size": schema.StringAttribute{
+							Computed: true,
+						},
+						"architecture": schema.StringAttribute{
+							Computed: true,
+						},
+						"num_accelerators": schema.Int32Attribute{
+							Computed: true,
+						},
+						"memory_gb": schema.Int32Attribute{
+							Computed: true,
+						},
+						"gpu_memory_gb": schema.Int32Attribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"available": schema.BoolAttribute{
+							Computed: true,
+						},
+						"price_per_hour": schema.Float64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *computeCatalogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*huggingfaceProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *huggingfaceProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
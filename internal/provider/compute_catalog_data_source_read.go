@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+	"github.com/sebps/terraform-provider-huggingface/internal/states"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Read refreshes the Terraform state with the latest data.
+func (d *computeCatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state states.ComputeCatalogDataSourceState
+
+	// Get configuration into the model
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := state.Filter
+	if filter == nil {
+		filter = &states.ComputeCatalogDataSourceFilter{}
+	}
+
+	// Vendor and region restrict the regions queried, everything else is filtered client-side
+	catalog, err := api.ListCatalog(ctx, d.client, filter.Vendor.ValueString(), filter.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Huggingface Compute Catalog",
+			"Could not Read Huggingface Compute Catalog: "+err.Error(),
+		)
+		return
+	}
+
+	// Cheapest instances first, so the first match is the cheapest fit
+	slices.SortStableFunc(catalog, func(a, b api.Compute) int {
+		return cmp.Or(cmp.Compare(a.PricePerHour, b.PricePerHour), cmp.Compare(a.ID, b.ID))
+	})
+
+	state.Computes = []states.ComputeCatalogEntry{}
+	for _, compute := range catalog {
+		if !computeMatchesFilter(compute, filter) {
+			continue
+		}
+
+		state.Computes = append(state.Computes, states.ComputeCatalogEntry{
+			ID:              types.StringValue(compute.ID),
+			Vendor:          types.StringValue(compute.Vendor),
+			Region:          types.StringValue(compute.Region),
+			Accelerator:     types.StringValue(compute.Accelerator),
+			InstanceType:    types.StringValue(compute.InstanceType),
+			InstanceSize:    types.StringValue(compute.InstanceSize),
+			Architecture:    types.StringValue(compute.Architecture),
+			NumAccelerators: types.Int32Value(int32(compute.NumAccelerators)),
+			MemoryGb:        types.Int32Value(int32(compute.MemoryGb)),
+			GpuMemoryGb:     types.Int32Value(int32(compute.GpuMemoryGb)),
+			Status:          types.StringValue(compute.Status),
+			Available:       types.BoolValue(compute.Status == api.StatusAvailable),
+			PricePerHour:    types.Float64Value(compute.PricePerHour),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// computeMatchesFilter reports whether the instance satisfies every criteria
+// set on the filter.
+func computeMatchesFilter(compute api.Compute, filter *states.ComputeCatalogDataSourceFilter) bool {
+	if accelerator := filter.Accelerator.ValueString(); accelerator != "" && compute.Accelerator != accelerator {
+		return false
+	}
+	if instanceType := filter.InstanceType.ValueString(); instanceType != "" && compute.InstanceType != instanceType {
+		return false
+	}
+	if !filter.MinAccelerators.IsNull() && compute.NumAccelerators < int(filter.MinAccelerators.ValueInt32()) {
+		return false
+	}
+	if !filter.MinMemoryGb.IsNull() && compute.MemoryGb < int(filter.MinMemoryGb.ValueInt32()) {
+		return false
+	}
+	if !filter.MinGpuMemoryGb.IsNull() && compute.GpuMemoryGb < int(filter.MinGpuMemoryGb.ValueInt32()) {
+		return false
+	}
+	if !filter.MaxPricePerHour.IsNull() && compute.PricePerHour > filter.MaxPricePerHour.ValueFloat64() {
+		return false
+	}
+	if filter.AvailableOnly.ValueBool() && compute.Status != api.StatusAvailable {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccComputeCatalogDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "huggingface_compute_catalog" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.huggingface_compute_catalog.test", "computes.0.id"),
+					resource.TestCheckResourceAttrSet("data.huggingface_compute_catalog.test", "computes.0.vendor"),
+					resource.TestCheckResourceAttrSet("data.huggingface_compute_catalog.test", "computes.0.region"),
+					resource.TestCheckResourceAttrSet("data.huggingface_compute_catalog.test", "computes.0.price_per_hour"),
+				),
+			},
+			// Cheapest available GPU with at least 24GB in eu-west-1
+			{
+				Config: providerConfig + `data "huggingface_compute_catalog" "test" {
+					filter = {
+						vendor            = "aws"
+						region            = "eu-west-1"
+						accelerator       = "gpu"
+						min_gpu_memory_gb = 24
+						available_only    = true
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.huggingface_compute_catalog.test", "computes.0.region", "eu-west-1"),
+					resource.TestCheckResourceAttr("data.huggingface_compute_catalog.test", "computes.0.accelerator", "gpu"),
+					resource.TestCheckResourceAttr("data.huggingface_compute_catalog.test", "computes.0.available", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &endpointDataSource{}
+	_ datasource.DataSourceWithConfigure = &endpointDataSource{}
+)
+
+func NewEndpointDataSource() datasource.DataSource {
+	return &endpointDataSource{}
+}
+
+type endpointDataSource struct {
+	endpoints *api.EndpointReader
+}
+
+func (d *endpointDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoint"
+}
+
+func (d *endpointDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := endpointDataSourceAttributes()
+
+	// The endpoint is looked up by namespace and name
+	attributes["namespace"] = schema.StringAttribute{
+		Required: true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Required: true,
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *endpointDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*huggingfaceProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *huggingfaceProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.endpoints = providerData.Endpoints
+}
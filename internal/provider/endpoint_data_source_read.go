@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
+	"github.com/sebps/terraform-provider-huggingface/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Read refreshes the Terraform state with the latest data.
+func (d *endpointDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config models.Endpoint
+
+	// Get configuration into the model
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := config.Namespace.ValueString()
+	name := config.Name.ValueString()
+	endpoint, err := d.endpoints.GetEndpoint(namespace, name)
+	if api.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Huggingface Endpoint Not Found",
+			"No Huggingface Endpoint named "+name+" exists in namespace "+namespace+".",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Huggingface Endpoint",
+			"Could not Read Huggingface Endpoint "+namespace+"/"+name+": "+err.Error(),
+		)
+		return
+	}
+
+	state, diags := transformers.FromProviderToDataSourceModel(ctx, endpoint)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// inject namespace and id
+	state.Namespace = types.StringValue(namespace)
+	state.ID = utils.GenerateStringID(namespace, name)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
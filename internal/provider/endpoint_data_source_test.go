@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+func TestAccEndpointDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				PreConfig: func() {
+					testAccSeedEndpoint(t, "test-terraform-2")
+				},
+				Config: providerConfig + `data "huggingface_endpoint" "test" {
+					namespace = "` + testAccNamespace + `"
+					name      = "test-terraform-2"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.huggingface_endpoint.test", "id", testAccNamespace+"/test-terraform-2"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoint.test", "type", "protected"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoint.test", "cloud_provider.vendor", "aws"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoint.test", "compute.accelerator", "cpu"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoint.test", "model.repository", "openai-community/gpt2"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoint.test", "status.state", "running"),
+					resource.TestCheckResourceAttrSet("data.huggingface_endpoint.test", "status.url"),
+				),
+			},
+			// Missing endpoint
+			{
+				Config: providerConfig + `data "huggingface_endpoint" "test" {
+					namespace = "` + testAccNamespace + `"
+					name      = "test-terraform-missing"
+				}`,
+				ExpectError: regexp.MustCompile(`Huggingface Endpoint Not Found`),
+			},
+		},
+	})
+}
+
+func TestEndpointDataSourceModel(t *testing.T) {
+	ctx := context.Background()
+
+	endpoint := testAccEndpoint("registry")
+	endpoint.Model.Image = huggingface.EndpointModelImage{
+		Custom: &huggingface.CustomImage{
+			URL: "registry.example.com/gpt2:latest",
+			Credentials: &huggingface.Credentials{
+				Username: "robot",
+			},
+		},
+	}
+
+	read, diags := transformers.FromProviderToDataSourceModel(ctx, &huggingface.EndpointWithStatus{
+		Name:     endpoint.Name,
+		Type:     endpoint.Type,
+		Provider: endpoint.Provider,
+		Compute:  endpoint.Compute,
+		Model:    endpoint.Model,
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	var schemaResp datasource.SchemaResponse
+	NewEndpointDataSource().Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &read); diags.HasError() {
+		t.Fatalf("expected the model to match the data source schema: %s", diags)
+	}
+
+	var username types.String
+	diags = state.GetAttribute(ctx, path.Root("model").AtName("image").AtName("custom").AtName("credentials").AtName("username"), &username)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if username.ValueString() != "robot" {
+		t.Errorf("expected the username read from the API, got %s", username)
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/states"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// endpointsFilter narrows down the endpoints listed by the huggingface_endpoints
+// data source. Empty criteria match every endpoint.
+type endpointsFilter struct {
+	tag              string
+	states           []string
+	vendor           string
+	region           string
+	accelerator      string
+	nameRegex        *regexp.Regexp
+	repositoryPrefix string
+}
+
+// newEndpointsFilter builds the filter from its configuration. A nil
+// configuration yields a filter matching every endpoint.
+func newEndpointsFilter(ctx context.Context, config *states.EndpointsDataSourceFilter) (*endpointsFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	filter := &endpointsFilter{}
+	if config == nil {
+		return filter, diags
+	}
+
+	filter.tag = config.Tag.ValueString()
+	filter.vendor = config.Vendor.ValueString()
+	filter.region = config.Region.ValueString()
+	filter.accelerator = config.Accelerator.ValueString()
+	filter.repositoryPrefix = config.RepositoryPrefix.ValueString()
+
+	if !config.States.IsNull() {
+		diags.Append(config.States.ElementsAs(ctx, &filter.states, false)...)
+	}
+
+	if nameRegex := config.NameRegex.ValueString(); nameRegex != "" {
+		compiled, err := regexp.Compile(nameRegex)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("filter").AtName("name_regex"),
+				"Invalid Name Regex",
+				"Could not compile name_regex "+nameRegex+": "+err.Error(),
+			)
+		}
+		filter.nameRegex = compiled
+	}
+
+	return filter, diags
+}
+
+// tags returns the tag query parameter pushed down to the list API, or nil
+// when no tag filter is set.
+func (f *endpointsFilter) tags() *string {
+	if f.tag == "" {
+		return nil
+	}
+
+	tags := url.QueryEscape(f.tag)
+	return &tags
+}
+
+// matches reports whether the endpoint satisfies every criteria of the filter.
+// The tag is checked again so results stay correct should the API ignore it.
+func (f *endpointsFilter) matches(endpoint *huggingface.EndpointWithStatus) bool {
+	if f.tag != "" && !slices.Contains(endpoint.Tags, f.tag) {
+		return false
+	}
+	if len(f.states) > 0 && !slices.Contains(f.states, string(endpoint.Status.State)) {
+		return false
+	}
+	if f.vendor != "" && endpoint.Provider.Vendor != f.vendor {
+		return false
+	}
+	if f.region != "" && endpoint.Provider.Region != f.region {
+		return false
+	}
+	if f.accelerator != "" && string(endpoint.Compute.Accelerator) != f.accelerator {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(endpoint.Name) {
+		return false
+	}
+	if f.repositoryPrefix != "" && !strings.HasPrefix(endpoint.Model.Repository, f.repositoryPrefix) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/states"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+func TestEndpointsFilter(t *testing.T) {
+	endpoint := &huggingface.EndpointWithStatus{
+		Name: "gpt2-prod",
+		Tags: []string{"team-a", "prod"},
+		Provider: huggingface.EndpointProvider{
+			Vendor: "aws",
+			Region: "us-east-1",
+		},
+		Compute: huggingface.EndpointCompute{
+			Accelerator: huggingface.AcceleratorCPU,
+		},
+		Model: huggingface.EndpointModel{
+			Repository: "openai-community/gpt2",
+		},
+		Status: huggingface.EndpointStatus{
+			State: huggingface.StateRunning,
+		},
+	}
+
+	testCases := map[string]struct {
+		filter   *states.EndpointsDataSourceFilter
+		expected bool
+	}{
+		"no filter": {
+			filter:   nil,
+			expected: true,
+		},
+		"empty filter": {
+			filter:   &states.EndpointsDataSourceFilter{},
+			expected: true,
+		},
+		"all criteria": {
+			filter: &states.EndpointsDataSourceFilter{
+				Tag:              types.StringValue("prod"),
+				States:           types.SetValueMust(types.StringType, []attr.Value{types.StringValue("running"), types.StringValue("paused")}),
+				Vendor:           types.StringValue("aws"),
+				Region:           types.StringValue("us-east-1"),
+				Accelerator:      types.StringValue("cpu"),
+				NameRegex:        types.StringValue("^gpt2-"),
+				RepositoryPrefix: types.StringValue("openai-community/"),
+			},
+			expected: true,
+		},
+		"tag": {
+			filter:   &states.EndpointsDataSourceFilter{Tag: types.StringValue("team-b")},
+			expected: false,
+		},
+		"states": {
+			filter:   &states.EndpointsDataSourceFilter{States: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("paused")})},
+			expected: false,
+		},
+		"vendor": {
+			filter:   &states.EndpointsDataSourceFilter{Vendor: types.StringValue("azure")},
+			expected: false,
+		},
+		"region": {
+			filter:   &states.EndpointsDataSourceFilter{Region: types.StringValue("eu-west-1")},
+			expected: false,
+		},
+		"accelerator": {
+			filter:   &states.EndpointsDataSourceFilter{Accelerator: types.StringValue("gpu")},
+			expected: false,
+		},
+		"name regex": {
+			filter:   &states.EndpointsDataSourceFilter{NameRegex: types.StringValue("-staging$")},
+			expected: false,
+		},
+		"repository prefix": {
+			filter:   &states.EndpointsDataSourceFilter{RepositoryPrefix: types.StringValue("meta-llama/")},
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			filter, diags := newEndpointsFilter(context.Background(), testCase.filter)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if matches := filter.matches(endpoint); matches != testCase.expected {
+				t.Errorf("expected match %t, got %t", testCase.expected, matches)
+			}
+		})
+	}
+}
+
+func TestEndpointsFilterTags(t *testing.T) {
+	filter, _ := newEndpointsFilter(context.Background(), nil)
+	if filter.tags() != nil {
+		t.Errorf("expected no tags without a tag filter, got %q", *filter.tags())
+	}
+
+	filter, _ = newEndpointsFilter(context.Background(), &states.EndpointsDataSourceFilter{Tag: types.StringValue("team a")})
+	if tags := filter.tags(); tags == nil || *tags != "team+a" {
+		t.Errorf("expected an escaped tag, got %v", tags)
+	}
+}
+
+func TestEndpointsFilterInvalidRegex(t *testing.T) {
+	_, diags := newEndpointsFilter(context.Background(), &states.EndpointsDataSourceFilter{NameRegex: types.StringValue("(")})
+	if !diags.HasError() {
+		t.Fatal("expected an invalid name_regex to be reported")
+	}
+}
@@ -3,10 +3,13 @@ package provider
 import (
 	"context"
 
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
 	"github.com/sebps/terraform-provider-huggingface/internal/states"
 	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
+	"github.com/sebps/terraform-provider-huggingface/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Read refreshes the Terraform state with the latest data.
@@ -20,8 +23,15 @@ func (d *endpointsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	filter, diags := newEndpointsFilter(ctx, state.Filter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Tags are filtered by the API, everything else client-side
 	namespace := state.Namespace.ValueString()
-	endpoints, err := d.client.ListEndpoints(namespace, nil)
+	endpoints, err := d.client.ListEndpoints(namespace, filter.tags())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Huggingface Endpoints",
@@ -30,15 +40,27 @@ func (d *endpointsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	state.Endpoints = []models.Endpoint{}
+	state.IDs = []types.String{}
+	state.ByName = map[string]models.Endpoint{}
 	for _, endpoint := range endpoints {
-		endpointState, diags := transformers.FromProviderToModel(ctx, &endpoint)
+		if !filter.matches(&endpoint) {
+			continue
+		}
+
+		endpointState, diags := transformers.FromProviderToDataSourceModel(ctx, &endpoint)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		// Add deeper nested mapping (compute, model, experimental_features, etc.) as needed
+		// inject namespace and id
+		endpointState.Namespace = types.StringValue(namespace)
+		endpointState.ID = utils.GenerateStringID(namespace, endpoint.Name)
+
 		state.Endpoints = append(state.Endpoints, endpointState)
+		state.IDs = append(state.IDs, endpointState.ID)
+		state.ByName[endpoint.Name] = endpointState
 	}
 
 	diags = resp.State.Set(ctx, &state)
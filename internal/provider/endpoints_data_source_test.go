@@ -12,13 +12,16 @@ func TestAccEndpointsDataSource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: providerConfig + `data "huggingface_endpoints" "test" { 
-					namespace = "<YOUR_NAMESPACE>" 
+				PreConfig: func() {
+					testAccSeedEndpoint(t, "test-terraform-0")
+				},
+				Config: providerConfig + `data "huggingface_endpoints" "test" {
+					namespace = "` + testAccNamespace + `"
 				}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify number of coffees returned
+					// Verify number of endpoints returned
 					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "endpoints.#", "1"),
-					// Verify the first coffee to ensure all attributes are set
+					// Verify the first endpoint to ensure all attributes are set
 					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "endpoints.0.name", "test-terraform-0"),
 					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "endpoints.0.type", "protected"),
 					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "endpoints.0.cloud_provider.vendor", "aws"),
@@ -26,6 +29,29 @@ func TestAccEndpointsDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "endpoints.0.compute.accelerator", "cpu"),
 				),
 			},
+			// Filter testing
+			{
+				PreConfig: func() {
+					testAccSeedEndpoint(t, "test-terraform-filtered")
+				},
+				Config: providerConfig + `data "huggingface_endpoints" "test" {
+					namespace = "` + testAccNamespace + `"
+
+					filter = {
+						name_regex        = "-filtered$"
+						states            = ["running"]
+						vendor            = "aws"
+						repository_prefix = "openai-community/"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "endpoints.#", "1"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "ids.0", testAccNamespace+"/test-terraform-filtered"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "by_name.%", "1"),
+					resource.TestCheckResourceAttr("data.huggingface_endpoints.test", "by_name.test-terraform-filtered.cloud_provider.region", "us-east-1"),
+				),
+			},
 		},
 	})
 }
@@ -4,19 +4,32 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &endpointsResource{}
-	_ resource.ResourceWithConfigure = &endpointsResource{}
+	_ resource.Resource                     = &endpointsResource{}
+	_ resource.ResourceWithConfigure        = &endpointsResource{}
+	_ resource.ResourceWithImportState      = &endpointsResource{}
+	_ resource.ResourceWithConfigValidators = &endpointsResource{}
+	_ resource.ResourceWithModifyPlan       = &endpointsResource{}
 )
 
 func NewEndpointsResource() resource.Resource {
@@ -24,7 +37,13 @@ func NewEndpointsResource() resource.Resource {
 }
 
 type endpointsResource struct {
-	client *huggingface.Client
+	client           *huggingface.Client
+	endpoints        *api.EndpointReader
+	defaultNamespace string
+	defaultTags      []string
+	catalog          *api.CatalogCache
+	hubURL           string
+	maxHourlyBudget  *float64
 }
 
 // Metadata returns the resource type name.
@@ -33,7 +52,7 @@ func (r *endpointsResource) Metadata(_ context.Context, req resource.MetadataReq
 }
 
 // Schema defines the schema for the resource.
-func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *endpointsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -43,15 +62,19 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"namespace": schema.StringAttribute{
-				Required: true,
+				Description: "Namespace of the endpoint, defaults to the provider default_namespace. An endpoint using the default is replaced when default_namespace changes.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"type": schema.StringAttribute{
@@ -62,9 +85,15 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Attributes: map[string]schema.Attribute{
 					"vendor": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"region": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 				},
 			},
@@ -112,10 +141,16 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 							"scale_to_zero_timeout": schema.Int32Attribute{
 								Computed: true,
 								Optional: true,
+								Validators: []validator.Int32{
+									int32validator.AtLeast(0),
+								},
 							},
 							"threshold": schema.Float64Attribute{
 								Computed: true,
 								Optional: true,
+								Validators: []validator.Float64{
+									float64validator.Between(0, 100),
+								},
 							},
 						},
 					},
@@ -127,12 +162,58 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					"repository": schema.StringAttribute{
 						Required: true,
 					},
+					"revision": schema.StringAttribute{
+						Description: "Branch, tag or commit SHA of the model repository, defaults to the main branch. " +
+							"The endpoint is pinned to the commit the revision resolves to when it is applied.",
+						Optional: true,
+					},
+					"resolved_sha": schema.StringAttribute{
+						Description: "Commit SHA of the model repository the endpoint is pinned to, resolved from revision on the Hub.",
+						Computed:    true,
+					},
+					"track_revision": schema.BoolAttribute{
+						Description: "Resolve revision on the Hub at every plan, so a moved branch shows up as a change of resolved_sha " +
+							"and rolls the new commit out on apply.",
+						Optional: true,
+					},
 					"framework": schema.StringAttribute{
 						Required: true,
 					},
 					"task": schema.StringAttribute{
 						Required: true,
 					},
+					"env": schema.MapAttribute{
+						Description: "Environment variables of the model container. Variables removed from the configuration are removed from the endpoint.",
+						ElementType: types.StringType,
+						Computed:    true,
+						Optional:    true,
+						Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+					},
+					"secrets": schema.MapAttribute{
+						Description: "Secret environment variables of the model container, stored in the state. Prefer secrets_wo " +
+							"with Terraform 1.11 and later. The API never returns their values, so changes made outside of Terraform are not detected.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						Validators: []validator.Map{
+							mapvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("secrets_wo")),
+						},
+					},
+					"secrets_wo": schema.MapAttribute{
+						Description: "Secret environment variables of the model container, never stored in the plan or state. " +
+							"Requires Terraform 1.11 or later. Change secrets_wo_version to send new values.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						WriteOnly:   true,
+					},
+					"secrets_wo_version": schema.Int32Attribute{
+						Description: "Version of secrets_wo. Terraform cannot detect changes of write-only values, change it to update the secrets.",
+						Optional:    true,
+						Validators: []validator.Int32{
+							int32validator.AlsoRequires(path.MatchRelative().AtParent().AtName("secrets_wo")),
+						},
+					},
 					"image": schema.SingleNestedAttribute{
 						Required: true,
 						Attributes: map[string]schema.Attribute{
@@ -315,8 +396,8 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 									},
 								},
 							},
+							// Not computed, as it holds the write-only registry password
 							"custom": schema.SingleNestedAttribute{
-								Computed: true,
 								Optional: true,
 								Attributes: map[string]schema.Attribute{
 									"url": schema.StringAttribute{
@@ -329,13 +410,33 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 										Computed: true,
 									},
 									"credentials": schema.SingleNestedAttribute{
-										Computed: true,
+										Description: "Credentials of the container registry the image is pulled from.",
+										Optional:    true,
 										Attributes: map[string]schema.Attribute{
 											"username": schema.StringAttribute{
 												Required: true,
 											},
 											"password": schema.StringAttribute{
-												Required: true,
+												Description: "Registry password, stored in the state. Prefer password_wo with Terraform 1.11 and later.",
+												Optional:    true,
+												Sensitive:   true,
+												Validators: []validator.String{
+													stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("password_wo")),
+												},
+											},
+											"password_wo": schema.StringAttribute{
+												Description: "Registry password, never stored in the plan or state. Requires Terraform 1.11 or later. " +
+													"Change password_wo_version to send a new password.",
+												Optional:  true,
+												Sensitive: true,
+												WriteOnly: true,
+											},
+											"password_wo_version": schema.Int32Attribute{
+												Description: "Version of password_wo. Terraform cannot detect changes of write-only values, change it to rotate the password.",
+												Optional:    true,
+												Validators: []validator.Int32{
+													int32validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password_wo")),
+												},
 											},
 										},
 									},
@@ -346,10 +447,16 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"tags": schema.ListAttribute{
+				Description: "Tags of the endpoint, excluding the provider default tags. The current tags of the endpoint are kept when unset.",
 				ElementType: types.StringType,
 				Computed:    true,
 				Optional:    true,
 			},
+			"tags_all": schema.ListAttribute{
+				Description: "Tags of the endpoint including the provider default tags.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 			"cache_http_responses": schema.BoolAttribute{
 				Computed: true,
 				Optional: true,
@@ -402,6 +509,27 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
+			"desired_state": schema.StringAttribute{
+				Description: "Desired lifecycle state of the endpoint, one of running, paused or scaled_to_zero. Left unmanaged when unset. A scaled to zero endpoint satisfies running, as it wakes up on the next request, and is not resumed.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(desiredStates...),
+				},
+			},
+			"estimated_hourly_cost_usd": schema.Float64Attribute{
+				Description: "Estimated hourly cost of the endpoint running max_replica replicas, in USD, from the hardware catalog pricing.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"estimated_max_monthly_cost_usd": schema.Float64Attribute{
+				Description: "Estimated monthly cost of the endpoint running max_replica replicas around the clock, in USD.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"status": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
@@ -462,6 +590,13 @@ func (r *endpointsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -473,20 +608,21 @@ func (r *endpointsResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*huggingface.Client)
+	providerData, ok := req.ProviderData.(*huggingfaceProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *huggingface.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *huggingfaceProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
-}
-
-func (r *endpointsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.client = providerData.Client
+	r.endpoints = providerData.Endpoints
+	r.defaultNamespace = providerData.DefaultNamespace
+	r.defaultTags = providerData.DefaultTags
+	r.catalog = providerData.Catalog
+	r.hubURL = providerData.HubURL
+	r.maxHourlyBudget = providerData.MaxHourlyBudget
 }
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// hoursPerMonth is the average number of hours in a month.
+const hoursPerMonth = 730
+
+var (
+	maxReplicaPath  = path.Root("compute").AtName("scaling").AtName("max_replica")
+	hourlyCostPath  = path.Root("estimated_hourly_cost_usd")
+	monthlyCostPath = path.Root("estimated_max_monthly_cost_usd")
+)
+
+// estimateCosts returns the hourly and monthly cost of the instance running
+// max replicas, from the catalog pricing. Both are null when the instance is
+// not part of the catalog.
+func estimateCosts(catalog []api.Compute, options computeOptions, maxReplica int32) (hourly, monthly types.Float64) {
+	for _, compute := range catalog {
+		if compute.Vendor == options.vendor &&
+			compute.Region == options.region &&
+			compute.Accelerator == options.accelerator &&
+			compute.InstanceType == options.instanceType &&
+			compute.InstanceSize == options.instanceSize {
+			hourlyCost := compute.PricePerHour * float64(maxReplica)
+			return types.Float64Value(roundCost(hourlyCost)), types.Float64Value(roundCost(hourlyCost * hoursPerMonth))
+		}
+	}
+
+	return types.Float64Null(), types.Float64Null()
+}
+
+// roundCost drops the floating point noise of price multiplications.
+func roundCost(cost float64) float64 {
+	return math.Round(cost*10000) / 10000
+}
+
+// checkCostCeiling blocks plans going over the provider hourly budget and
+// warns when a change raises the cost ceiling of an existing endpoint.
+func (r *endpointsResource) checkCostCeiling(hourlyCost, currentHourlyCost types.Float64, resp *resource.ModifyPlanResponse) {
+	if hourlyCost.IsNull() {
+		return
+	}
+
+	if r.maxHourlyBudget != nil && hourlyCost.ValueFloat64() > *r.maxHourlyBudget {
+		resp.Diagnostics.AddAttributeError(
+			maxReplicaPath,
+			"Hourly Budget Exceeded",
+			fmt.Sprintf(
+				"The endpoint may cost up to $%.4f per hour at max_replica, above the provider max_hourly_budget_usd of $%.4f.",
+				hourlyCost.ValueFloat64(),
+				*r.maxHourlyBudget,
+			),
+		)
+		return
+	}
+
+	if !currentHourlyCost.IsNull() && !currentHourlyCost.IsUnknown() && hourlyCost.ValueFloat64() > currentHourlyCost.ValueFloat64() {
+		resp.Diagnostics.AddWarning(
+			"Endpoint Cost Ceiling Increased",
+			fmt.Sprintf(
+				"The endpoint may now cost up to $%.4f per hour ($%.2f per month), up from $%.4f per hour.",
+				hourlyCost.ValueFloat64(),
+				hourlyCost.ValueFloat64()*hoursPerMonth,
+				currentHourlyCost.ValueFloat64(),
+			),
+		)
+	}
+}
+
+// refreshCosts estimates the costs of the endpoint stored in state from the
+// catalog pricing. The estimates of prior are kept when the instance and
+// max_replica did not change, so refreshing unchanged endpoints never lists
+// the catalog, and when the catalog cannot be listed, as refreshing them is
+// best effort.
+func (r *endpointsResource) refreshCosts(ctx context.Context, prior, state attributeGetter, hourly, monthly types.Float64) (types.Float64, types.Float64) {
+	if r.catalog == nil {
+		return hourly, monthly
+	}
+
+	options, known, diags := readComputeOptions(ctx, state)
+	var maxReplica types.Int32
+	diags.Append(state.GetAttribute(ctx, maxReplicaPath, &maxReplica)...)
+	if !known || maxReplica.IsNull() || diags.HasError() {
+		return hourly, monthly
+	}
+
+	priorOptions, priorKnown, diags := readComputeOptions(ctx, prior)
+	var priorMaxReplica types.Int32
+	diags.Append(prior.GetAttribute(ctx, maxReplicaPath, &priorMaxReplica)...)
+	if priorKnown && !diags.HasError() && priorOptions == options && priorMaxReplica.Equal(maxReplica) && !hourly.IsNull() {
+		return hourly, monthly
+	}
+
+	catalog, err := r.catalog.Catalog(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to refresh endpoint cost estimates", map[string]any{"error": err.Error()})
+		return hourly, monthly
+	}
+
+	return estimateCosts(catalog, options, maxReplica.ValueInt32())
+}
+
+// knownOrNull returns null for unknown values, which must not be stored in state.
+func knownOrNull(value types.Float64) types.Float64 {
+	if value.IsUnknown() {
+		return types.Float64Null()
+	}
+
+	return value
+}
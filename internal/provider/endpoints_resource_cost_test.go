@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+	"github.com/sebps/terraform-provider-huggingface/internal/testserver"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEstimateCosts(t *testing.T) {
+	testCases := map[string]struct {
+		options         computeOptions
+		maxReplica      int32
+		expectedHourly  types.Float64
+		expectedMonthly types.Float64
+	}{
+		"single replica": {
+			options:         computeOptions{vendor: "aws", region: "us-east-1", accelerator: "cpu", instanceType: "intel-icl", instanceSize: "x4"},
+			maxReplica:      1,
+			expectedHourly:  types.Float64Value(0.134),
+			expectedMonthly: types.Float64Value(97.82),
+		},
+		"max replicas": {
+			options:         computeOptions{vendor: "aws", region: "us-east-1", accelerator: "gpu", instanceType: "nvidia-l4", instanceSize: "x1"},
+			maxReplica:      3,
+			expectedHourly:  types.Float64Value(2.4),
+			expectedMonthly: types.Float64Value(1752),
+		},
+		"not in catalog": {
+			options:         computeOptions{vendor: "aws", region: "us-east-1", accelerator: "gpu", instanceType: "nvidia-h100", instanceSize: "x8"},
+			maxReplica:      1,
+			expectedHourly:  types.Float64Null(),
+			expectedMonthly: types.Float64Null(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			hourly, monthly := estimateCosts(testserver.Computes, testCase.options, testCase.maxReplica)
+			if !hourly.Equal(testCase.expectedHourly) {
+				t.Errorf("expected hourly cost %s, got %s", testCase.expectedHourly, hourly)
+			}
+			if !monthly.Equal(testCase.expectedMonthly) {
+				t.Errorf("expected monthly cost %s, got %s", testCase.expectedMonthly, monthly)
+			}
+		})
+	}
+}
+
+func TestCheckCostCeiling(t *testing.T) {
+	budget := 1.0
+
+	testCases := map[string]struct {
+		budget          *float64
+		hourlyCost      types.Float64
+		currentCost     types.Float64
+		expectedError   string
+		expectedWarning string
+	}{
+		"unknown cost": {
+			budget:      &budget,
+			hourlyCost:  types.Float64Null(),
+			currentCost: types.Float64Null(),
+		},
+		"new endpoint within budget": {
+			budget:      &budget,
+			hourlyCost:  types.Float64Value(0.8),
+			currentCost: types.Float64Null(),
+		},
+		"new endpoint over budget": {
+			budget:        &budget,
+			hourlyCost:    types.Float64Value(1.6),
+			currentCost:   types.Float64Null(),
+			expectedError: "Hourly Budget Exceeded",
+		},
+		"no budget": {
+			hourlyCost:  types.Float64Value(1000),
+			currentCost: types.Float64Null(),
+		},
+		"raised ceiling": {
+			hourlyCost:      types.Float64Value(1.6),
+			currentCost:     types.Float64Value(0.8),
+			expectedWarning: "Endpoint Cost Ceiling Increased",
+		},
+		"lowered ceiling": {
+			hourlyCost:  types.Float64Value(0.8),
+			currentCost: types.Float64Value(1.6),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &endpointsResource{maxHourlyBudget: testCase.budget}
+			resp := &resource.ModifyPlanResponse{}
+
+			r.checkCostCeiling(testCase.hourlyCost, testCase.currentCost, resp)
+
+			errors := resp.Diagnostics.Errors()
+			if testCase.expectedError == "" && len(errors) > 0 {
+				t.Fatalf("unexpected errors: %v", errors)
+			}
+			if testCase.expectedError != "" && (len(errors) != 1 || errors[0].Summary() != testCase.expectedError) {
+				t.Fatalf("expected a %q error, got %v", testCase.expectedError, errors)
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if testCase.expectedWarning == "" && len(warnings) > 0 {
+				t.Fatalf("unexpected warnings: %v", warnings)
+			}
+			if testCase.expectedWarning != "" && (len(warnings) != 1 || warnings[0].Summary() != testCase.expectedWarning) {
+				t.Fatalf("expected a %q warning, got %v", testCase.expectedWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestRefreshCosts(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestEndpointsResource(t)
+	r.catalog = api.NewCatalogCache(r.client)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	endpoint := func(instanceSize string, maxReplica int32) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		// in the order of computeOptionPaths
+		values := []string{"aws", "us-east-1", "cpu", "intel-icl", instanceSize}
+		for i, attributePath := range computeOptionPaths {
+			if diags := state.SetAttribute(ctx, attributePath, values[i]); diags.HasError() {
+				t.Fatal(diags)
+			}
+		}
+		if diags := state.SetAttribute(ctx, maxReplicaPath, maxReplica); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return state
+	}
+
+	// Unchanged endpoints keep their estimates without listing the catalog
+	stale := types.Float64Value(1)
+	hourly, monthly := r.refreshCosts(ctx, endpoint("x4", 1), endpoint("x4", 1), stale, stale)
+	if !hourly.Equal(stale) || !monthly.Equal(stale) {
+		t.Errorf("expected the estimates to be kept, got %s and %s", hourly, monthly)
+	}
+
+	// A change made outside of Terraform is estimated again
+	hourly, monthly = r.refreshCosts(ctx, endpoint("x4", 1), endpoint("x4", 2), stale, stale)
+	if !hourly.Equal(types.Float64Value(0.268)) || !monthly.Equal(types.Float64Value(195.64)) {
+		t.Errorf("expected the estimates of 2 replicas, got %s and %s", hourly, monthly)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
 	"github.com/sebps/terraform-provider-huggingface/internal/states"
 	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
 
@@ -22,6 +23,12 @@ func (r *endpointsResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var id, name, namespace string
 	if !plan.ID.IsNull() && !plan.ID.IsUnknown() {
 		// extract id
@@ -36,10 +43,28 @@ func (r *endpointsResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Define endpoint to create from plan
-	endpointToCreate := transformers.FromModelToProvider(ctx, &plan)
+	endpointToCreate, diags := transformers.FromModelToProvider(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	// send the write-only secrets and registry password of the configuration
+	resp.Diagnostics.Append(setWriteOnlyModel(ctx, req.Config, &endpointToCreate.Model.Secrets, &endpointToCreate.Model.Image)...)
+
+	// send the provider default tags along with the endpoint tags
+	resp.Diagnostics.Append(plan.TagsAll.ElementsAs(ctx, &endpointToCreate.Tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create new endpoint
 	endpointCreated, err := r.client.CreateEndpoint(namespace, endpointToCreate)
+	if api.IsConflict(err) {
+		resp.Diagnostics.AddError(
+			"Endpoint Already Exists",
+			"Could not create endpoint "+namespace+"/"+endpointToCreate.Name+" because an endpoint with this name already exists. "+
+				"When replacing an endpoint with create_before_destroy, the replacement must use a different name.",
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating endpoint",
@@ -48,27 +73,65 @@ func (r *endpointsResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	// inject name
+	name = endpointCreated.Name
+
+	// Wait for the endpoint to be up and running
+	endpointReady, waitErr := r.waitForEndpointState(ctx, namespace, name, endpointReadyStates, createTimeout)
+	if endpointReady == nil {
+		endpointReady = endpointCreated
+	}
+
+	// Pause or scale the endpoint to zero when requested
+	if waitErr == nil {
+		endpointReady, waitErr = r.applyDesiredState(ctx, namespace, name, plan.DesiredState, true, endpointReady, createTimeout)
+	}
+
 	// Map back plan from updated endpoint
-	updatedPlan, diags := transformers.FromProviderToModel(ctx, endpointCreated)
+	endpointState, diags := transformers.FromProviderToModel(ctx, endpointReady)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// inject namespace
-	updatedPlan.Namespace = types.StringValue(namespace)
+	endpointState.Namespace = types.StringValue(namespace)
 
-	// inject name
-	name = endpointCreated.Name
+	// keep the secrets and revision settings the API does not return
+	resp.Diagnostics.Append(keepModelConfiguration(&endpointState, plan.Endpoint)...)
+
+	// keep the provider default tags out of tags
+	tagsAll, diags := r.splitDefaultTags(ctx, &endpointState, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// inject id
 	id = fmt.Sprintf("%s/%s", namespace, name)
-	updatedPlan.ID = types.StringValue(id)
+	endpointState.ID = types.StringValue(id)
 
-	// Set state to fully populated data
+	// Set state to fully populated data, even when waiting failed, so the
+	// endpoint is tracked and marked as tainted rather than orphaned.
+	updatedPlan := states.EndpointResourceState{
+		Endpoint:                   endpointState,
+		TagsAll:                    tagsAll,
+		DesiredState:               plan.DesiredState,
+		EstimatedHourlyCostUSD:     knownOrNull(plan.EstimatedHourlyCostUSD),
+		EstimatedMaxMonthlyCostUSD: knownOrNull(plan.EstimatedMaxMonthlyCostUSD),
+		Timeouts:                   plan.Timeouts,
+	}
 	diags = resp.State.Set(ctx, updatedPlan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for endpoint creation",
+			"Endpoint "+id+" was created but did not become ready: "+waitErr.Error(),
+		)
+		return
+	}
 }
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	namespacePath = path.Root("namespace")
+	tagsPath      = path.Root("tags")
+	tagsAllPath   = path.Root("tags_all")
+)
+
+// planProviderDefaults fills the namespace of the plan from the provider
+// default_namespace and merges the provider default tags into tags_all. An
+// endpoint without a namespace of its own follows default_namespace, being
+// replaced when it changes.
+func (r *endpointsResource) planProviderDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	creating := req.State.Raw.IsNull()
+
+	var configNamespace types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, namespacePath, &configNamespace)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configNamespace.IsNull() {
+		if r.defaultNamespace == "" {
+			resp.Diagnostics.AddAttributeError(
+				namespacePath,
+				"Missing Endpoint Namespace",
+				"The endpoint namespace is not set and the provider has no default_namespace. Set either of them.",
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, namespacePath, r.defaultNamespace)...)
+
+		// The RequiresReplace plan modifier ran before the default was applied
+		if !creating {
+			var currentNamespace types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, namespacePath, &currentNamespace)...)
+			if currentNamespace.ValueString() != r.defaultNamespace {
+				resp.RequiresReplace = append(resp.RequiresReplace, namespacePath)
+			}
+		}
+	}
+
+	// Tags left unset keep the tags of the endpoint, none for a new one
+	var tags, configTags types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, tagsPath, &tags)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tagsPath, &configTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if configTags.IsNull() {
+		tags = types.ListValueMust(types.StringType, nil)
+		if !creating {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, tagsPath, &tags)...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsPath, tags)...)
+	}
+
+	resourceTags, known, diags := knownTags(ctx, tags)
+	resp.Diagnostics.Append(diags...)
+	if !known || resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsAllPath, types.ListUnknown(types.StringType))...)
+		return
+	}
+
+	tagsAll := mergeTags(resourceTags, r.defaultTags)
+
+	// Keep the order of the current tags to avoid a diff on reordered tags
+	if !creating {
+		var currentTagsAll types.List
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, tagsAllPath, &currentTagsAll)...)
+		current, known, diags := knownTags(ctx, currentTagsAll)
+		resp.Diagnostics.Append(diags...)
+		if known && sameTags(current, tagsAll) {
+			tagsAll = current
+		}
+	}
+
+	planTagsAll, diags := types.ListValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsAllPath, planTagsAll)...)
+}
+
+// splitDefaultTags moves the tags read from the API to tags_all and keeps in
+// tags only those not coming from the provider default tags, unless they are
+// also part of the configured tags, so defaults never show up as a diff.
+func (r *endpointsResource) splitDefaultTags(ctx context.Context, endpoint *models.Endpoint, configured types.List) (types.List, diag.Diagnostics) {
+	var apiTags, configuredTags []string
+	diags := endpoint.Tags.ElementsAs(ctx, &apiTags, false)
+	if !configured.IsNull() && !configured.IsUnknown() {
+		diags.Append(configured.ElementsAs(ctx, &configuredTags, false)...)
+	}
+	if diags.HasError() {
+		return types.ListNull(types.StringType), diags
+	}
+
+	// An endpoint without tags has an empty tags_all, as planned
+	tagsAll, d := types.ListValueFrom(ctx, types.StringType, mergeTags(apiTags))
+	diags.Append(d...)
+
+	resourceTags := make([]string, 0, len(apiTags))
+	for _, tag := range apiTags {
+		if slices.Contains(r.defaultTags, tag) && !slices.Contains(configuredTags, tag) {
+			continue
+		}
+		resourceTags = append(resourceTags, tag)
+	}
+
+	// The API may not keep the configured order
+	if configuredTags != nil && sameTags(resourceTags, configuredTags) {
+		resourceTags = configuredTags
+	}
+
+	endpoint.Tags, d = types.ListValueFrom(ctx, types.StringType, resourceTags)
+	diags.Append(d...)
+
+	return tagsAll, diags
+}
+
+// knownTags returns the tags of the list, false when any of them is unknown.
+func knownTags(ctx context.Context, list types.List) ([]string, bool, diag.Diagnostics) {
+	if list.IsUnknown() {
+		return nil, false, nil
+	}
+
+	var elements []types.String
+	diags := list.ElementsAs(ctx, &elements, false)
+
+	tags := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if element.IsUnknown() {
+			return nil, false, diags
+		}
+		tags = append(tags, element.ValueString())
+	}
+
+	return tags, true, diags
+}
+
+// mergeTags concatenates tag lists, keeping the first occurrence of each tag.
+func mergeTags(lists ...[]string) []string {
+	merged := []string{}
+	for _, tags := range lists {
+		for _, tag := range tags {
+			if !slices.Contains(merged, tag) {
+				merged = append(merged, tag)
+			}
+		}
+	}
+
+	return merged
+}
+
+// sameTags reports whether both lists hold the same tags in any order.
+func sameTags(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
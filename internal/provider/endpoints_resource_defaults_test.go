@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSplitDefaultTags(t *testing.T) {
+	testCases := map[string]struct {
+		apiTags         []string
+		configuredTags  []string
+		expectedTags    []string
+		expectedTagsAll []string
+	}{
+		"no tags": {
+			expectedTags:    []string{},
+			expectedTagsAll: []string{},
+		},
+		"defaults only": {
+			apiTags:         []string{"managed-by:terraform", "team:ml"},
+			expectedTags:    []string{},
+			expectedTagsAll: []string{"managed-by:terraform", "team:ml"},
+		},
+		"resource and default tags": {
+			apiTags:         []string{"gpt2", "team:ml", "staging"},
+			configuredTags:  []string{"gpt2", "staging"},
+			expectedTags:    []string{"gpt2", "staging"},
+			expectedTagsAll: []string{"gpt2", "team:ml", "staging"},
+		},
+		"default tag also configured": {
+			apiTags:         []string{"gpt2", "team:ml"},
+			configuredTags:  []string{"gpt2", "team:ml"},
+			expectedTags:    []string{"gpt2", "team:ml"},
+			expectedTagsAll: []string{"gpt2", "team:ml"},
+		},
+		"tag added outside terraform": {
+			apiTags:         []string{"gpt2", "adhoc"},
+			configuredTags:  []string{"gpt2"},
+			expectedTags:    []string{"gpt2", "adhoc"},
+			expectedTagsAll: []string{"gpt2", "adhoc"},
+		},
+	}
+
+	ctx := context.Background()
+	r := &endpointsResource{defaultTags: []string{"managed-by:terraform", "team:ml"}}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			endpoint := models.Endpoint{Tags: types.ListValueMust(types.StringType, nil)}
+			if testCase.apiTags != nil {
+				endpoint.Tags, _ = types.ListValueFrom(ctx, types.StringType, testCase.apiTags)
+			}
+			configured := types.ListNull(types.StringType)
+			if testCase.configuredTags != nil {
+				configured, _ = types.ListValueFrom(ctx, types.StringType, testCase.configuredTags)
+			}
+
+			tagsAll, diags := r.splitDefaultTags(ctx, &endpoint, configured)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+
+			var tags, allTags []string
+			endpoint.Tags.ElementsAs(ctx, &tags, false)
+			tagsAll.ElementsAs(ctx, &allTags, false)
+			if !slices.Equal(tags, testCase.expectedTags) {
+				t.Errorf("expected tags %v, got %v", testCase.expectedTags, tags)
+			}
+			if !slices.Equal(allTags, testCase.expectedTagsAll) {
+				t.Errorf("expected tags_all %v, got %v", testCase.expectedTagsAll, allTags)
+			}
+		})
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	merged := mergeTags([]string{"gpt2", "team:ml"}, []string{"managed-by:terraform", "team:ml"})
+	expected := []string{"gpt2", "team:ml", "managed-by:terraform"}
+	if !slices.Equal(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+}
+
+func TestPlanProviderDefaultsNamespace(t *testing.T) {
+	testCases := map[string]struct {
+		defaultNamespace  string
+		configNamespace   types.String
+		stateNamespace    types.String
+		expectedNamespace string
+		expectedReplace   bool
+		expectedError     bool
+	}{
+		"default on create": {
+			defaultNamespace:  "team-a",
+			expectedNamespace: "team-a",
+		},
+		"unchanged default": {
+			defaultNamespace:  "team-a",
+			stateNamespace:    types.StringValue("team-a"),
+			expectedNamespace: "team-a",
+		},
+		"changed default": {
+			defaultNamespace:  "team-b",
+			stateNamespace:    types.StringValue("team-a"),
+			expectedNamespace: "team-b",
+			expectedReplace:   true,
+		},
+		"namespace removed from config": {
+			defaultNamespace:  "team-a",
+			stateNamespace:    types.StringValue("custom"),
+			expectedNamespace: "team-a",
+			expectedReplace:   true,
+		},
+		"configured namespace": {
+			defaultNamespace:  "team-b",
+			configNamespace:   types.StringValue("team-a"),
+			stateNamespace:    types.StringValue("team-a"),
+			expectedNamespace: "team-a",
+		},
+		"no default": {
+			stateNamespace: types.StringValue("team-a"),
+			expectedError:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &endpointsResource{defaultNamespace: testCase.defaultNamespace}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			endpoint := func(namespace types.String) tfsdk.State {
+				state := tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				}
+				if diags := state.SetAttribute(ctx, path.Root("namespace"), namespace); diags.HasError() {
+					t.Fatal(diags)
+				}
+				return state
+			}
+
+			config := endpoint(testCase.configNamespace)
+			// UseStateForUnknown keeps the namespace of the state in the plan
+			plan := endpoint(testCase.configNamespace)
+			if testCase.configNamespace.IsNull() {
+				plan = endpoint(testCase.stateNamespace)
+			}
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if !testCase.stateNamespace.IsNull() {
+				state = endpoint(testCase.stateNamespace)
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:  state,
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			r.planProviderDefaults(ctx, req, &resp)
+
+			if testCase.expectedError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected a missing namespace error")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var namespace types.String
+			if diags := resp.Plan.GetAttribute(ctx, path.Root("namespace"), &namespace); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if namespace.ValueString() != testCase.expectedNamespace {
+				t.Errorf("expected the namespace %q, got %s", testCase.expectedNamespace, namespace)
+			}
+			if replace := resp.RequiresReplace.Contains(path.Root("namespace")); replace != testCase.expectedReplace {
+				t.Errorf("expected replacement %t, got %t", testCase.expectedReplace, replace)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
 	"github.com/sebps/terraform-provider-huggingface/internal/states"
 )
 
@@ -18,6 +20,12 @@ func (r *endpointsResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var id, name, namespace string
 	if !state.ID.IsNull() {
 		// extract id
@@ -37,6 +45,10 @@ func (r *endpointsResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	// Delete endpoint
 	err := r.client.DeleteEndpoint(namespace, name)
+	if api.IsNotFound(err) {
+		tflog.Info(ctx, "Endpoint already deleted", map[string]any{"id": namespace + "/" + name})
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting endpoint",
@@ -44,4 +56,14 @@ func (r *endpointsResource) Delete(ctx context.Context, req resource.DeleteReque
 		)
 		return
 	}
+
+	// Wait for the endpoint to be torn down so its name can be reused
+	err = r.waitForEndpointDeletion(ctx, namespace, name, deleteTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for endpoint deletion",
+			"Endpoint "+namespace+"/"+name+" was not deleted: "+err.Error(),
+		)
+		return
+	}
 }
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+)
+
+// testDeleteRequest returns the request deleting the endpoint name of the
+// test namespace.
+func testDeleteRequest(t *testing.T, r *endpointsResource, name string) resource.DeleteRequest {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.SetAttribute(ctx, path.Root("id"), testAccNamespace+"/"+name); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	return resource.DeleteRequest{State: state}
+}
+
+func TestDelete(t *testing.T) {
+	r, server := newTestEndpointsResource(t)
+	server.SeedEndpoint(testAccNamespace, testAccEndpoint("delete"))
+
+	var resp resource.DeleteResponse
+	r.Delete(context.Background(), testDeleteRequest(t, r, "delete"), &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	// Delete returns once the API answers 404, not as soon as it accepts
+	// the deletion, when the endpoint would still be returned once
+	if _, err := r.client.GetEndpoint(testAccNamespace, "delete"); !api.IsNotFound(err) {
+		t.Fatalf("expected the endpoint to be not found, got: %v", err)
+	}
+}
+
+func TestDeleteAlreadyDeleted(t *testing.T) {
+	r, _ := newTestEndpointsResource(t)
+
+	var resp resource.DeleteResponse
+	r.Delete(context.Background(), testDeleteRequest(t, r, "gone"), &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected deleting a missing endpoint to succeed, got: %s", resp.Diagnostics)
+	}
+}
+
+func TestWaitForEndpointDeletion(t *testing.T) {
+	r, server := newTestEndpointsResource(t)
+	server.SeedEndpoint(testAccNamespace, testAccEndpoint("deleting"))
+	if err := r.client.DeleteEndpoint(testAccNamespace, "deleting"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fake API returns the endpoint being deleted once before the 404
+	if err := r.waitForEndpointDeletion(context.Background(), testAccNamespace, "deleting", time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	server.SeedEndpoint(testAccNamespace, testAccEndpoint("stuck"))
+	err := r.waitForEndpointDeletion(context.Background(), testAccNamespace, "stuck", 20*time.Millisecond)
+	if err == nil || err.Error() != `timeout while waiting for endpoint terraform-acc/stuck to be deleted, last state "running"` {
+		t.Fatalf("expected a timeout, got: %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// Values accepted by the desired_state attribute.
+const (
+	desiredStateRunning      = "running"
+	desiredStatePaused       = "paused"
+	desiredStateScaledToZero = "scaled_to_zero"
+)
+
+var desiredStates = []string{
+	desiredStateRunning,
+	desiredStatePaused,
+	desiredStateScaledToZero,
+}
+
+// applyDesiredState pauses, resumes or scales the endpoint to zero when its
+// current state does not satisfy the desired one, then waits for the
+// transition. A scaled to zero endpoint satisfies running and is not resumed,
+// as it wakes up on the next request. A running endpoint satisfies
+// scaled_to_zero too, unless the desired state has just changed, as changed
+// reports, so an endpoint woken up by traffic is not scaled down again by an
+// unrelated update. A null or unknown desired state leaves the endpoint
+// untouched.
+func (r *endpointsResource) applyDesiredState(
+	ctx context.Context,
+	namespace, name string,
+	desiredState types.String,
+	changed bool,
+	endpoint *huggingface.EndpointWithStatus,
+	timeout time.Duration,
+) (*huggingface.EndpointWithStatus, error) {
+	if desiredState.IsNull() || desiredState.IsUnknown() {
+		return endpoint, nil
+	}
+
+	currentState := endpoint.Status.State
+	if desiredStateSatisfied(desiredState.ValueString(), currentState) &&
+		!(changed && desiredState.ValueString() == desiredStateScaledToZero && currentState != huggingface.StateScaledToZero) {
+		return endpoint, nil
+	}
+
+	var (
+		err     error
+		targets []huggingface.EndpointState
+	)
+
+	switch desiredState.ValueString() {
+	case desiredStateRunning:
+		err = r.client.ResumeEndpoint(namespace, name)
+		targets = endpointReadyStates
+	case desiredStatePaused:
+		err = r.client.PauseEndpoint(namespace, name)
+		targets = []huggingface.EndpointState{huggingface.StatePaused}
+	case desiredStateScaledToZero:
+		err = r.client.ScaleEndpointToZero(namespace, name)
+		targets = []huggingface.EndpointState{huggingface.StateScaledToZero}
+	default:
+		return endpoint, fmt.Errorf("unsupported desired state %q", desiredState.ValueString())
+	}
+	if err != nil {
+		return endpoint, err
+	}
+
+	tflog.Info(ctx, "Moving endpoint to desired state", map[string]any{
+		"id":            namespace + "/" + name,
+		"current_state": string(currentState),
+		"desired_state": desiredState.ValueString(),
+	})
+
+	return r.waitForEndpointState(ctx, namespace, name, targets, timeout)
+}
+
+// reconcileDesiredState maps the observed endpoint state back onto the
+// desired_state attribute. The configured value is kept whenever the observed
+// state satisfies it, so autoscaling between running and scaled to zero never
+// shows up as a diff. Only a real drift, such as an endpoint paused or resumed
+// outside of Terraform, is reported.
+func reconcileDesiredState(desiredState types.String, currentState huggingface.EndpointState) types.String {
+	if desiredState.IsNull() || desiredState.IsUnknown() {
+		return desiredState
+	}
+
+	if desiredStateSatisfied(desiredState.ValueString(), currentState) {
+		return desiredState
+	}
+
+	if currentState == huggingface.StatePaused {
+		return types.StringValue(desiredStatePaused)
+	}
+
+	return types.StringValue(desiredStateRunning)
+}
+
+// desiredStateSatisfied reports whether an endpoint in currentState is
+// consistent with desiredState. Only pausing is a deliberate state: running
+// and scaled to zero are both satisfied by any state but paused, which is
+// also why endpointReadyStates include scaled to zero.
+func desiredStateSatisfied(desiredState string, currentState huggingface.EndpointState) bool {
+	if desiredState == desiredStatePaused {
+		return currentState == huggingface.StatePaused
+	}
+
+	return currentState != huggingface.StatePaused
+}
+
+// desiredStateOnlyAttributes are the attributes that can change without
+// updating the endpoint itself.
+var desiredStateOnlyAttributes = []string{"desired_state", "timeouts"}
+
+// onlyDesiredStateChanged reports whether the plan of an update differs from
+// the state in desiredStateOnlyAttributes alone, so pausing or resuming an
+// endpoint does not redeploy it first. Values left unknown by the plan are the
+// computed ones awaiting the update and match any state.
+func onlyDesiredStateChanged(plan, state tftypes.Value) (bool, error) {
+	var planAttributes, stateAttributes map[string]tftypes.Value
+	if err := plan.As(&planAttributes); err != nil {
+		return false, err
+	}
+	if err := state.As(&stateAttributes); err != nil {
+		return false, err
+	}
+
+	for name, planValue := range planAttributes {
+		if slices.Contains(desiredStateOnlyAttributes, name) {
+			continue
+		}
+
+		matches, err := planMatchesState(planValue, stateAttributes[name])
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// planMatchesState reports whether the known parts of a planned value equal
+// the state value.
+func planMatchesState(plan, state tftypes.Value) (bool, error) {
+	switch {
+	case !plan.IsKnown():
+		return true, nil
+	case plan.IsNull() || state.IsNull() || !state.IsKnown():
+		return plan.Equal(state), nil
+	}
+
+	switch plan.Type().(type) {
+	case tftypes.Object, tftypes.Map:
+		var planElements, stateElements map[string]tftypes.Value
+		if err := plan.As(&planElements); err != nil {
+			return false, err
+		}
+		if err := state.As(&stateElements); err != nil {
+			return false, err
+		}
+		if len(planElements) != len(stateElements) {
+			return false, nil
+		}
+
+		for key, planElement := range planElements {
+			stateElement, ok := stateElements[key]
+			if !ok {
+				return false, nil
+			}
+			if matches, err := planMatchesState(planElement, stateElement); err != nil || !matches {
+				return false, err
+			}
+		}
+
+		return true, nil
+	case tftypes.List, tftypes.Tuple:
+		var planElements, stateElements []tftypes.Value
+		if err := plan.As(&planElements); err != nil {
+			return false, err
+		}
+		if err := state.As(&stateElements); err != nil {
+			return false, err
+		}
+		if len(planElements) != len(stateElements) {
+			return false, nil
+		}
+
+		for i := range planElements {
+			if matches, err := planMatchesState(planElements[i], stateElements[i]); err != nil || !matches {
+				return false, err
+			}
+		}
+
+		return true, nil
+	default:
+		return plan.Equal(state), nil
+	}
+}
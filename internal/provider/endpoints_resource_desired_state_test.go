@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/testserver"
+)
+
+// newTestEndpointsResource returns a resource calling a fake API of its own,
+// polling it without delay.
+func newTestEndpointsResource(t *testing.T) (*endpointsResource, *testserver.Server) {
+	t.Helper()
+
+	server := testserver.New()
+	t.Cleanup(server.Close)
+
+	token := "hf_test"
+	client, err := huggingface.NewClient(&server.URL, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pollInterval := endpointPollInterval
+	endpointPollInterval = time.Millisecond
+	t.Cleanup(func() { endpointPollInterval = pollInterval })
+
+	return &endpointsResource{client: client}, server
+}
+
+func TestReconcileDesiredState(t *testing.T) {
+	testCases := map[string]struct {
+		desiredState types.String
+		currentState huggingface.EndpointState
+		expected     types.String
+	}{
+		"unmanaged": {
+			desiredState: types.StringNull(),
+			currentState: huggingface.StatePaused,
+			expected:     types.StringNull(),
+		},
+		"running": {
+			desiredState: types.StringValue(desiredStateRunning),
+			currentState: huggingface.StateRunning,
+			expected:     types.StringValue(desiredStateRunning),
+		},
+		"running scaled to zero": {
+			desiredState: types.StringValue(desiredStateRunning),
+			currentState: huggingface.StateScaledToZero,
+			expected:     types.StringValue(desiredStateRunning),
+		},
+		"running paused": {
+			desiredState: types.StringValue(desiredStateRunning),
+			currentState: huggingface.StatePaused,
+			expected:     types.StringValue(desiredStatePaused),
+		},
+		"paused": {
+			desiredState: types.StringValue(desiredStatePaused),
+			currentState: huggingface.StatePaused,
+			expected:     types.StringValue(desiredStatePaused),
+		},
+		"paused resumed": {
+			desiredState: types.StringValue(desiredStatePaused),
+			currentState: huggingface.StateInitializing,
+			expected:     types.StringValue(desiredStateRunning),
+		},
+		"scaled to zero woken up": {
+			desiredState: types.StringValue(desiredStateScaledToZero),
+			currentState: huggingface.StateRunning,
+			expected:     types.StringValue(desiredStateScaledToZero),
+		},
+		"scaled to zero paused": {
+			desiredState: types.StringValue(desiredStateScaledToZero),
+			currentState: huggingface.StatePaused,
+			expected:     types.StringValue(desiredStatePaused),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := reconcileDesiredState(testCase.desiredState, testCase.currentState); !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyDesiredState(t *testing.T) {
+	testCases := map[string]struct {
+		desiredState  types.String
+		changed       bool
+		currentState  huggingface.EndpointState
+		expectedState huggingface.EndpointState
+	}{
+		"unmanaged": {
+			desiredState:  types.StringNull(),
+			changed:       true,
+			currentState:  huggingface.StatePaused,
+			expectedState: huggingface.StatePaused,
+		},
+		"pause": {
+			desiredState:  types.StringValue(desiredStatePaused),
+			changed:       true,
+			currentState:  huggingface.StateRunning,
+			expectedState: huggingface.StatePaused,
+		},
+		"pause scaled to zero": {
+			desiredState:  types.StringValue(desiredStatePaused),
+			currentState:  huggingface.StateScaledToZero,
+			expectedState: huggingface.StatePaused,
+		},
+		"resume": {
+			desiredState:  types.StringValue(desiredStateRunning),
+			changed:       true,
+			currentState:  huggingface.StatePaused,
+			expectedState: huggingface.StateRunning,
+		},
+		"running scaled to zero not resumed": {
+			desiredState:  types.StringValue(desiredStateRunning),
+			changed:       true,
+			currentState:  huggingface.StateScaledToZero,
+			expectedState: huggingface.StateScaledToZero,
+		},
+		"scale to zero": {
+			desiredState:  types.StringValue(desiredStateScaledToZero),
+			changed:       true,
+			currentState:  huggingface.StateRunning,
+			expectedState: huggingface.StateScaledToZero,
+		},
+		"scaled to zero woken up not scaled down": {
+			desiredState:  types.StringValue(desiredStateScaledToZero),
+			currentState:  huggingface.StateRunning,
+			expectedState: huggingface.StateRunning,
+		},
+		"scale paused to zero": {
+			desiredState:  types.StringValue(desiredStateScaledToZero),
+			currentState:  huggingface.StatePaused,
+			expectedState: huggingface.StateScaledToZero,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, server := newTestEndpointsResource(t)
+			server.SeedEndpoint(testAccNamespace, testAccEndpoint("desired-state"))
+			server.SetState(testAccNamespace, "desired-state", testCase.currentState, "")
+			endpoint, _ := server.Endpoint(testAccNamespace, "desired-state")
+
+			got, err := r.applyDesiredState(context.Background(), testAccNamespace, "desired-state", testCase.desiredState, testCase.changed, &endpoint, time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.State != testCase.expectedState {
+				t.Errorf("expected state %q, got %q", testCase.expectedState, got.Status.State)
+			}
+
+			stored, _ := server.Endpoint(testAccNamespace, "desired-state")
+			if stored.Status.State != testCase.expectedState {
+				t.Errorf("expected the API to report state %q, got %q", testCase.expectedState, stored.Status.State)
+			}
+		})
+	}
+}
+
+func TestOnlyDesiredStateChanged(t *testing.T) {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"desired_state": tftypes.String,
+		"scaling": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"max_replica": tftypes.Number,
+		}},
+		"tags":   tftypes.List{ElementType: tftypes.String},
+		"status": tftypes.String,
+	}}
+	endpoint := func(desiredState string, maxReplica int, tags []string, status tftypes.Value) tftypes.Value {
+		tagValues := make([]tftypes.Value, 0, len(tags))
+		for _, tag := range tags {
+			tagValues = append(tagValues, tftypes.NewValue(tftypes.String, tag))
+		}
+
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"desired_state": tftypes.NewValue(tftypes.String, desiredState),
+			"scaling": tftypes.NewValue(objectType.AttributeTypes["scaling"], map[string]tftypes.Value{
+				"max_replica": tftypes.NewValue(tftypes.Number, maxReplica),
+			}),
+			"tags":   tftypes.NewValue(objectType.AttributeTypes["tags"], tagValues),
+			"status": status,
+		})
+	}
+	running := tftypes.NewValue(tftypes.String, "running")
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	state := endpoint("running", 1, []string{"gpt2"}, running)
+
+	testCases := map[string]struct {
+		plan     tftypes.Value
+		expected bool
+	}{
+		"desired state": {
+			plan:     endpoint("paused", 1, []string{"gpt2"}, unknown),
+			expected: true,
+		},
+		"nested attribute": {
+			plan: endpoint("paused", 2, []string{"gpt2"}, unknown),
+		},
+		"list element": {
+			plan: endpoint("paused", 1, []string{"gpt2", "team:ml"}, unknown),
+		},
+		"computed attribute": {
+			plan: endpoint("paused", 1, []string{"gpt2"}, tftypes.NewValue(tftypes.String, "paused")),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := onlyDesiredStateChanged(testCase.plan, state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/states"
+	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
+	"github.com/sebps/terraform-provider-huggingface/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ImportState imports an existing endpoint from a namespace/name or bare name ID.
+func (r *endpointsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespace, name, err := utils.ParseImportID(req.ID, r.defaultNamespace)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected an import ID of the form <namespace>/<name> or <name>, got "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Get endpoint value from Huggingface
+	endpoint, err := r.endpoints.GetEndpoint(namespace, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Huggingface Endpoint",
+			"Could not read Huggingface Endpoint "+namespace+"/"+name+": "+err.Error(),
+		)
+		return
+	}
+
+	endpointState, diags := transformers.FromProviderToModel(ctx, endpoint)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// inject namespace
+	endpointState.Namespace = types.StringValue(namespace)
+
+	// inject id
+	endpointState.ID = utils.GenerateStringID(namespace, name)
+
+	// keep the provider default tags out of tags
+	tagsAll, diags := r.splitDefaultTags(ctx, &endpointState, types.ListNull(types.StringType))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importedState := states.EndpointResourceState{
+		Endpoint:     endpointState,
+		TagsAll:      tagsAll,
+		DesiredState: types.StringNull(),
+	}
+
+	// keep the null timeouts of the empty import state
+	diags = resp.State.GetAttribute(ctx, path.Root("timeouts"), &importedState.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set imported state
+	diags = resp.State.Set(ctx, &importedState)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// keptModelAttributes are the model attributes the API does not return, or
+// returns in another form, kept from the plan or state.
+var keptModelAttributes = []string{"revision", "track_revision", "secrets", "secrets_wo_version"}
+
+// keptCredentialsAttributes are the model.image.custom.credentials attributes
+// kept from the plan or state, the API never returning the registry password.
+var keptCredentialsAttributes = []string{"password", "password_wo_version"}
+
+// keepModelConfiguration sets the model attributes of an endpoint read from
+// the API to those of the plan or state: secrets and the registry password,
+// which are never returned, revision, which is returned as the commit SHA it
+// resolved to, track_revision and the versions of the write-only attributes.
+// resolved_sha is kept too unless the API served a commit, so a revision
+// changed outside of Terraform shows up as a diff.
+func keepModelConfiguration(endpoint *models.Endpoint, from models.Endpoint) diag.Diagnostics {
+	if endpoint.Model.IsNull() || endpoint.Model.IsUnknown() || from.Model.IsNull() || from.Model.IsUnknown() {
+		return nil
+	}
+
+	attributes := make(map[string]attr.Value, len(endpoint.Model.Attributes()))
+	for name, value := range endpoint.Model.Attributes() {
+		attributes[name] = value
+	}
+
+	fromAttributes := from.Model.Attributes()
+	for _, name := range keptModelAttributes {
+		if value, ok := fromAttributes[name]; ok {
+			attributes[name] = value
+		}
+	}
+	if resolvedSHA, ok := attributes["resolved_sha"].(types.String); ok && resolvedSHA.IsNull() {
+		if value, ok := fromAttributes["resolved_sha"].(types.String); ok && !value.IsUnknown() {
+			attributes["resolved_sha"] = value
+		}
+	}
+
+	var diags diag.Diagnostics
+	if image, ok := attributes["image"].(types.Object); ok {
+		var d diag.Diagnostics
+		attributes["image"], d = keepCredentials(image, fromAttributes["image"])
+		diags.Append(d...)
+	}
+
+	model, d := types.ObjectValue(models.Model{}.AttributeTypes(), attributes)
+	diags.Append(d...)
+	if !diags.HasError() {
+		endpoint.Model = model
+	}
+
+	return diags
+}
+
+// keepCredentials sets the custom image credentials attributes of image to
+// those of the from image, when both of them have credentials.
+func keepCredentials(image types.Object, from attr.Value) (types.Object, diag.Diagnostics) {
+	fromImage, ok := from.(types.Object)
+	if !ok {
+		return image, nil
+	}
+
+	credentials, ok := nestedObject(image, "custom", "credentials")
+	if !ok {
+		return image, nil
+	}
+	fromCredentials, ok := nestedObject(fromImage, "custom", "credentials")
+	if !ok {
+		return image, nil
+	}
+
+	credentialsAttributes := make(map[string]attr.Value, len(credentials.Attributes()))
+	for name, value := range credentials.Attributes() {
+		credentialsAttributes[name] = value
+	}
+	for _, name := range keptCredentialsAttributes {
+		if value, ok := fromCredentials.Attributes()[name]; ok {
+			credentialsAttributes[name] = value
+		}
+	}
+
+	credentials, diags := types.ObjectValue(models.Credentials{}.AttributeTypes(), credentialsAttributes)
+	if diags.HasError() {
+		return image, diags
+	}
+
+	custom, _ := nestedObject(image, "custom")
+	customAttributes := make(map[string]attr.Value, len(custom.Attributes()))
+	for name, value := range custom.Attributes() {
+		customAttributes[name] = value
+	}
+	customAttributes["credentials"] = credentials
+
+	custom, d := types.ObjectValue(models.ModelImageCustom{}.AttributeTypes(), customAttributes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return image, diags
+	}
+
+	imageAttributes := make(map[string]attr.Value, len(image.Attributes()))
+	for name, value := range image.Attributes() {
+		imageAttributes[name] = value
+	}
+	imageAttributes["custom"] = custom
+
+	kept, d := types.ObjectValue(models.ModelImage{}.AttributeTypes(), imageAttributes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return image, diags
+	}
+
+	return kept, diags
+}
+
+// nestedObject returns the known, non-null object found by following names
+// from object.
+func nestedObject(object types.Object, names ...string) (types.Object, bool) {
+	for _, name := range names {
+		if object.IsNull() || object.IsUnknown() {
+			return object, false
+		}
+		nested, ok := object.Attributes()[name].(types.Object)
+		if !ok {
+			return object, false
+		}
+		object = nested
+	}
+
+	return object, !object.IsNull() && !object.IsUnknown()
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+func TestKeepModelConfiguration(t *testing.T) {
+	ctx := context.Background()
+
+	endpoint := testAccEndpoint("registry")
+	endpoint.Model.Image = huggingface.EndpointModelImage{
+		Custom: &huggingface.CustomImage{
+			URL: "registry.example.com/gpt2:latest",
+			Credentials: &huggingface.Credentials{
+				Username: "robot",
+			},
+		},
+	}
+	computeID := "aws-us-east-1-cpu-intel-icl-x4"
+	endpoint.Compute.ID = &computeID
+	apiEndpoint := huggingface.EndpointWithStatus{
+		Name:     endpoint.Name,
+		Type:     endpoint.Type,
+		Provider: endpoint.Provider,
+		Compute:  endpoint.Compute,
+		Model:    endpoint.Model,
+	}
+
+	read, diags := transformers.FromProviderToModel(ctx, &apiEndpoint)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// the plan or state the endpoint was read for
+	from := withModel(t, read, func(model *models.Model) {
+		model.Secrets = types.MapValueMust(types.StringType, map[string]attr.Value{"API_KEY": types.StringValue("s3cr3t")})
+		model.SecretsWOVersion = types.Int32Value(2)
+	})
+	from = withCredentials(t, from, func(credentials *models.Credentials) {
+		credentials.Password = types.StringValue("legacy")
+		credentials.PasswordWOVersion = types.Int32Value(3)
+	})
+
+	if diags := keepModelConfiguration(&read, from); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	var model models.Model
+	var image models.ModelImage
+	var custom models.ModelImageCustom
+	var credentials models.Credentials
+	diags.Append(read.Model.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	diags.Append(model.Image.As(ctx, &image, basetypes.ObjectAsOptions{})...)
+	diags.Append(image.Custom.As(ctx, &custom, basetypes.ObjectAsOptions{})...)
+	diags.Append(custom.Credentials.As(ctx, &credentials, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if model.Secrets.Elements()["API_KEY"] != types.StringValue("s3cr3t") {
+		t.Errorf("expected the secrets to be kept, got %s", model.Secrets)
+	}
+	if model.SecretsWOVersion.ValueInt32() != 2 {
+		t.Errorf("expected secrets_wo_version to be kept, got %s", model.SecretsWOVersion)
+	}
+	if credentials.Username.ValueString() != "robot" {
+		t.Errorf("expected the username read from the API, got %s", credentials.Username)
+	}
+	if credentials.Password.ValueString() != "legacy" {
+		t.Errorf("expected the password to be kept, got %s", credentials.Password)
+	}
+	if credentials.PasswordWOVersion.ValueInt32() != 3 {
+		t.Errorf("expected password_wo_version to be kept, got %s", credentials.PasswordWOVersion)
+	}
+	if !credentials.PasswordWO.IsNull() {
+		t.Errorf("expected password_wo to be null, got %s", credentials.PasswordWO)
+	}
+}
+
+// withModel returns a copy of endpoint with its model changed by update.
+func withModel(t *testing.T, endpoint models.Endpoint, update func(*models.Model)) models.Endpoint {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var model models.Model
+	diags := endpoint.Model.As(ctx, &model, basetypes.ObjectAsOptions{})
+	update(&model)
+
+	var d diag.Diagnostics
+	endpoint.Model, d = types.ObjectValueFrom(ctx, model.AttributeTypes(), model)
+	diags.Append(d...)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	return endpoint
+}
+
+// withCredentials returns a copy of endpoint with its custom image
+// credentials changed by update.
+func withCredentials(t *testing.T, endpoint models.Endpoint, update func(*models.Credentials)) models.Endpoint {
+	t.Helper()
+
+	return withModel(t, endpoint, func(model *models.Model) {
+		ctx := context.Background()
+
+		var image models.ModelImage
+		var custom models.ModelImageCustom
+		var credentials models.Credentials
+		var diags, d diag.Diagnostics
+		diags.Append(model.Image.As(ctx, &image, basetypes.ObjectAsOptions{})...)
+		diags.Append(image.Custom.As(ctx, &custom, basetypes.ObjectAsOptions{})...)
+		diags.Append(custom.Credentials.As(ctx, &credentials, basetypes.ObjectAsOptions{})...)
+
+		update(&credentials)
+
+		custom.Credentials, d = types.ObjectValueFrom(ctx, credentials.AttributeTypes(), credentials)
+		diags.Append(d...)
+		image.Custom, d = types.ObjectValueFrom(ctx, custom.AttributeTypes(), custom)
+		diags.Append(d...)
+		model.Image, d = types.ObjectValueFrom(ctx, image.AttributeTypes(), image)
+		diags.Append(d...)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxComputeAlternatives caps the alternatives suggested for invalid compute options.
+const maxComputeAlternatives = 3
+
+// computeOptionPaths are the attributes locating an instance in the catalog.
+var computeOptionPaths = []path.Path{
+	path.Root("cloud_provider").AtName("vendor"),
+	path.Root("cloud_provider").AtName("region"),
+	path.Root("compute").AtName("accelerator"),
+	path.Root("compute").AtName("instance_type"),
+	path.Root("compute").AtName("instance_size"),
+}
+
+// computeOptions is the instance requested by an endpoint plan.
+type computeOptions struct {
+	vendor       string
+	region       string
+	accelerator  string
+	instanceType string
+	instanceSize string
+}
+
+func (o computeOptions) String() string {
+	return fmt.Sprintf("%s %s %s", o.accelerator, o.instanceType, o.instanceSize)
+}
+
+// ModifyPlan applies the provider default namespace and tags, resolves the
+// model revision on the Hub, checks the requested instance against the
+// hardware catalog so unknown vendor, region or instance combinations fail at
+// plan time, and estimates the cost ceiling of the endpoint from the catalog
+// pricing.
+func (r *endpointsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.catalog == nil {
+		return
+	}
+
+	r.planProviderDefaults(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.planModelRevision(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options, known, diags := readComputeOptions(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+
+	var maxReplica types.Int32
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, maxReplicaPath, &maxReplica)...)
+	if !known || maxReplica.IsUnknown() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only check new or changed instances, so an instance retired from the
+	// catalog does not block unrelated updates of existing endpoints. The
+	// estimates are kept from state by their plan modifiers otherwise.
+	creating := req.State.Raw.IsNull()
+	var current computeOptions
+	var currentMaxReplica types.Int32
+	var currentHourlyCost types.Float64
+	if !creating {
+		current, _, diags = readComputeOptions(ctx, req.State)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, maxReplicaPath, &currentMaxReplica)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, hourlyCostPath, &currentHourlyCost)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if current == options && currentMaxReplica.Equal(maxReplica) {
+			return
+		}
+	}
+
+	catalog, err := r.catalog.Catalog(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Validate Compute Options",
+			"Could not list the Huggingface hardware catalog, compute options will be checked by the API at apply time: "+err.Error(),
+		)
+		return
+	}
+
+	if creating || current != options {
+		tflog.Debug(ctx, "Validating compute options against the hardware catalog", map[string]any{
+			"vendor":  options.vendor,
+			"region":  options.region,
+			"compute": options.String(),
+		})
+
+		validateComputeOptions(options, catalog, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	hourlyCost, monthlyCost := estimateCosts(catalog, options, maxReplica.ValueInt32())
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hourlyCostPath, hourlyCost)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, monthlyCostPath, monthlyCost)...)
+
+	r.checkCostCeiling(hourlyCost, currentHourlyCost, resp)
+}
+
+// attributeGetter is satisfied by both tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// readComputeOptions reads the instance from a plan or state. It returns
+// false when any of the options is not known yet.
+func readComputeOptions(ctx context.Context, source attributeGetter) (computeOptions, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]string, len(computeOptionPaths))
+	for i, attributePath := range computeOptionPaths {
+		var value types.String
+		diags.Append(source.GetAttribute(ctx, attributePath, &value)...)
+		if value.IsNull() || value.IsUnknown() {
+			return computeOptions{}, false, diags
+		}
+		values[i] = value.ValueString()
+	}
+
+	return computeOptions{
+		vendor:       values[0],
+		region:       values[1],
+		accelerator:  values[2],
+		instanceType: values[3],
+		instanceSize: values[4],
+	}, true, diags
+}
+
+// validateComputeOptions reports the requested instance when it is missing
+// from the catalog, along with the closest valid alternatives.
+func validateComputeOptions(options computeOptions, catalog []api.Compute, resp *resource.ModifyPlanResponse) {
+	var regionComputes []api.Compute
+	vendorRegions := map[string]bool{}
+	vendors := map[string]bool{}
+	for _, compute := range catalog {
+		vendors[compute.Vendor] = true
+		if compute.Vendor != options.vendor {
+			continue
+		}
+		vendorRegions[compute.Region] = true
+		if compute.Region == options.region {
+			regionComputes = append(regionComputes, compute)
+		}
+	}
+
+	switch {
+	case !vendors[options.vendor]:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cloud_provider").AtName("vendor"),
+			"Invalid Cloud Provider Vendor",
+			fmt.Sprintf("Vendor %q is not offered. Valid vendors are: %s.", options.vendor, sortedKeys(vendors)),
+		)
+		return
+	case !vendorRegions[options.region]:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cloud_provider").AtName("region"),
+			"Invalid Cloud Provider Region",
+			fmt.Sprintf("Region %q is not offered by vendor %q. Valid regions are: %s.", options.region, options.vendor, sortedKeys(vendorRegions)),
+		)
+		return
+	}
+
+	for _, compute := range regionComputes {
+		if compute.Accelerator == options.accelerator && compute.InstanceType == options.instanceType && compute.InstanceSize == options.instanceSize {
+			if compute.Status != "" && compute.Status != api.StatusAvailable {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("compute").AtName("instance_type"),
+					"Compute Currently Unavailable",
+					fmt.Sprintf("Instance %s is currently %s in %s/%s, the endpoint may not start.", options, compute.Status, options.vendor, options.region),
+				)
+			}
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("compute").AtName("instance_type"),
+		"Invalid Compute Options",
+		fmt.Sprintf(
+			"Instance %s (accelerator, instance_type, instance_size) is not offered in %s/%s. Closest valid alternatives:\n%s",
+			options, options.vendor, options.region, formatAlternatives(closestComputes(options, regionComputes)),
+		),
+	)
+}
+
+// closestComputes ranks the computes by edit distance to the requested instance.
+func closestComputes(options computeOptions, computes []api.Compute) []api.Compute {
+	type rankedCompute struct {
+		compute  api.Compute
+		distance int
+	}
+
+	ranked := make([]rankedCompute, 0, len(computes))
+	for _, compute := range computes {
+		candidate := computeOptions{
+			accelerator:  compute.Accelerator,
+			instanceType: compute.InstanceType,
+			instanceSize: compute.InstanceSize,
+		}
+		ranked = append(ranked, rankedCompute{
+			compute:  compute,
+			distance: levenshtein(options.String(), candidate.String()),
+		})
+	}
+	slices.SortStableFunc(ranked, func(a, b rankedCompute) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), cmp.Compare(a.compute.ID, b.compute.ID))
+	})
+
+	closest := make([]api.Compute, 0, maxComputeAlternatives)
+	for _, r := range ranked[:min(len(ranked), maxComputeAlternatives)] {
+		closest = append(closest, r.compute)
+	}
+
+	return closest
+}
+
+func formatAlternatives(computes []api.Compute) string {
+	lines := make([]string, 0, len(computes))
+	for _, compute := range computes {
+		lines = append(lines, fmt.Sprintf(
+			"  - accelerator = %q, instance_type = %q, instance_size = %q",
+			compute.Accelerator, compute.InstanceType, compute.InstanceSize,
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func sortedKeys(set map[string]bool) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return strings.Join(keys, ", ")
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+	"github.com/sebps/terraform-provider-huggingface/internal/testserver"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestValidateComputeOptions(t *testing.T) {
+	testCases := map[string]struct {
+		options          computeOptions
+		expectedSummary  string
+		expectedWarning  string
+		expectedInDetail []string
+	}{
+		"valid": {
+			options: computeOptions{vendor: "aws", region: "us-east-1", accelerator: "gpu", instanceType: "nvidia-l4", instanceSize: "x1"},
+		},
+		"unavailable": {
+			options:         computeOptions{vendor: "aws", region: "eu-west-1", accelerator: "gpu", instanceType: "nvidia-a100", instanceSize: "x1"},
+			expectedWarning: "Compute Currently Unavailable",
+		},
+		"unknown vendor": {
+			options:          computeOptions{vendor: "gcp", region: "us-east4", accelerator: "cpu", instanceType: "intel-icl", instanceSize: "x4"},
+			expectedSummary:  "Invalid Cloud Provider Vendor",
+			expectedInDetail: []string{"aws, azure"},
+		},
+		"unknown region": {
+			options:          computeOptions{vendor: "aws", region: "ap-south-1", accelerator: "cpu", instanceType: "intel-icl", instanceSize: "x4"},
+			expectedSummary:  "Invalid Cloud Provider Region",
+			expectedInDetail: []string{"eu-west-1, us-east-1"},
+		},
+		"unknown instance": {
+			options:         computeOptions{vendor: "aws", region: "us-east-1", accelerator: "gpu", instanceType: "nvidia-l4", instanceSize: "x8"},
+			expectedSummary: "Invalid Compute Options",
+			expectedInDetail: []string{
+				`instance_type = "nvidia-l4", instance_size = "x1"`,
+				`instance_type = "nvidia-t4", instance_size = "x1"`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.ModifyPlanResponse{}
+			validateComputeOptions(testCase.options, testserver.Computes, resp)
+
+			errors := resp.Diagnostics.Errors()
+			if testCase.expectedSummary == "" {
+				if len(errors) > 0 {
+					t.Fatalf("unexpected errors: %v", errors)
+				}
+			} else {
+				if len(errors) != 1 || errors[0].Summary() != testCase.expectedSummary {
+					t.Fatalf("expected a %q error, got %v", testCase.expectedSummary, errors)
+				}
+				for _, expected := range testCase.expectedInDetail {
+					if !strings.Contains(errors[0].Detail(), expected) {
+						t.Errorf("expected %q in error detail, got %q", expected, errors[0].Detail())
+					}
+				}
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if testCase.expectedWarning == "" && len(warnings) > 0 {
+				t.Fatalf("unexpected warnings: %v", warnings)
+			}
+			if testCase.expectedWarning != "" && (len(warnings) != 1 || warnings[0].Summary() != testCase.expectedWarning) {
+				t.Fatalf("expected a %q warning, got %v", testCase.expectedWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestClosestComputes(t *testing.T) {
+	computes := []api.Compute{
+		{ID: "a", Accelerator: "gpu", InstanceType: "nvidia-a10g", InstanceSize: "x1"},
+		{ID: "b", Accelerator: "gpu", InstanceType: "nvidia-a100", InstanceSize: "x1"},
+		{ID: "c", Accelerator: "cpu", InstanceType: "intel-icl", InstanceSize: "x4"},
+		{ID: "d", Accelerator: "gpu", InstanceType: "nvidia-a100", InstanceSize: "x2"},
+	}
+
+	closest := closestComputes(computeOptions{accelerator: "gpu", instanceType: "nvidia-a100", instanceSize: "x4"}, computes)
+
+	var ids []string
+	for _, compute := range closest {
+		ids = append(ids, compute.ID)
+	}
+	if strings.Join(ids, ",") != "b,d,a" {
+		t.Errorf("unexpected alternatives order: %v", ids)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"nvidia-l4", "nvidia-l4", 0},
+		{"nvidia-t4", "nvidia-l4", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, testCase := range testCases {
+		if distance := levenshtein(testCase.a, testCase.b); distance != testCase.expected {
+			t.Errorf("levenshtein(%q, %q): expected %d, got %d", testCase.a, testCase.b, testCase.expected, distance)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
 	"github.com/sebps/terraform-provider-huggingface/internal/states"
 	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
 
@@ -51,32 +52,69 @@ func (r *endpointsResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Get refreshed endpoint value from Huggingface
-	endpoint, err := r.client.GetEndpoint(namespace, name)
+	endpoint, err := r.endpoints.GetEndpoint(namespace, name)
+	if api.IsNotFound(err) {
+		// The endpoint was deleted outside of Terraform, let Terraform
+		// propose its recreation
+		tflog.Warn(ctx, "Endpoint not found, removing it from state", map[string]any{"id": namespace + "/" + name})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if api.IsUnauthorized(err) {
+		resp.Diagnostics.AddError(
+			"Unauthorized to Read Huggingface Endpoint",
+			"Could not read Huggingface Endpoint "+namespace+"/"+name+", check that the token has access to this namespace: "+err.Error(),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Huggingface Endpoint",
-			"Could not read Huggingface Endpoint "+plan.Namespace.ValueString()+"/"+plan.Name.ValueString()+": "+err.Error(),
+			"Could not read Huggingface Endpoint "+namespace+"/"+name+": "+err.Error(),
 		)
 		return
 	}
 
-	updatedPlan, diags := transformers.FromProviderToModel(ctx, endpoint)
+	endpointState, diags := transformers.FromProviderToModel(ctx, endpoint)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// inject namespace
-	updatedPlan.Namespace = types.StringValue(namespace)
+	endpointState.Namespace = types.StringValue(namespace)
+
+	// keep the secrets and revision settings the API does not return
+	resp.Diagnostics.Append(keepModelConfiguration(&endpointState, plan.Endpoint)...)
+
+	// keep the provider default tags out of tags
+	tagsAll, diags := r.splitDefaultTags(ctx, &endpointState, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// inject id
 	id = fmt.Sprintf("%s/%s", namespace, name)
-	updatedPlan.ID = types.StringValue(id)
+	endpointState.ID = types.StringValue(id)
 
 	// Set refreshed state
+	updatedPlan := states.EndpointResourceState{
+		Endpoint:                   endpointState,
+		TagsAll:                    tagsAll,
+		DesiredState:               reconcileDesiredState(plan.DesiredState, endpoint.Status.State),
+		EstimatedHourlyCostUSD:     plan.EstimatedHourlyCostUSD,
+		EstimatedMaxMonthlyCostUSD: plan.EstimatedMaxMonthlyCostUSD,
+		Timeouts:                   plan.Timeouts,
+	}
 	diags = resp.State.Set(ctx, &updatedPlan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Refresh cost estimates when the instance changed outside of Terraform
+	hourlyCost, monthlyCost := r.refreshCosts(ctx, req.State, resp.State, plan.EstimatedHourlyCostUSD, plan.EstimatedMaxMonthlyCostUSD)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, hourlyCostPath, hourlyCost)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, monthlyCostPath, monthlyCost)...)
 }
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	repositoryPath    = path.Root("model").AtName("repository")
+	revisionPath      = path.Root("model").AtName("revision")
+	resolvedSHAPath   = path.Root("model").AtName("resolved_sha")
+	trackRevisionPath = path.Root("model").AtName("track_revision")
+)
+
+// planModelRevision resolves the model revision to the commit SHA the
+// endpoint is pinned to. The revision is resolved when the endpoint is
+// created or its repository or revision change, and at every plan with
+// track_revision, so a moved branch shows up as a change of resolved_sha.
+func (r *endpointsResource) planModelRevision(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var repository, revision types.String
+	var trackRevision types.Bool
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, repositoryPath, &repository)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, revisionPath, &revision)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, trackRevisionPath, &trackRevision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if repository.IsUnknown() || revision.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, resolvedSHAPath, types.StringUnknown())...)
+		return
+	}
+
+	// unchanged is true for an update keeping the repository and revision
+	unchanged := false
+	currentResolvedSHA := types.StringNull()
+	if !req.State.Raw.IsNull() {
+		var currentRepository, currentRevision types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, repositoryPath, &currentRepository)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, revisionPath, &currentRevision)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, resolvedSHAPath, &currentResolvedSHA)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Keep the pinned commit unless asked to follow the revision
+		unchanged = currentRepository.Equal(repository) && currentRevision.Equal(revision)
+		if unchanged && !trackRevision.ValueBool() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, resolvedSHAPath, currentResolvedSHA)...)
+			return
+		}
+	}
+
+	modelRevision := revision.ValueString()
+	if modelRevision == "" {
+		modelRevision = api.DefaultRevision
+	}
+
+	resolvedSHA, err := api.ResolveRevision(ctx, r.client, r.hubURL, repository.ValueString(), modelRevision)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			revisionPath,
+			"Unable to Resolve Model Revision",
+			"Could not resolve revision "+modelRevision+" of model "+repository.ValueString()+" on the Hub, "+
+				"the endpoint will not be pinned to a commit: "+err.Error(),
+		)
+
+		// A tracked revision stays on its current commit
+		if unchanged {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, resolvedSHAPath, currentResolvedSHA)...)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, resolvedSHAPath, types.StringUnknown())...)
+		}
+		return
+	}
+
+	tflog.Debug(ctx, "Resolved model revision", map[string]any{
+		"repository":   repository.ValueString(),
+		"revision":     modelRevision,
+		"resolved_sha": resolvedSHA,
+	})
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, resolvedSHAPath, types.StringValue(resolvedSHA))...)
+}
@@ -1,9 +1,17 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	huggingface "github.com/sebps/huggingface-client/client"
 )
 
 func TestAccEndpointsResource(t *testing.T) {
@@ -14,7 +22,7 @@ func TestAccEndpointsResource(t *testing.T) {
 			{
 				Config: providerConfig + `
 					resource "huggingface_endpoint" "test" {
-						namespace = "<YOUR_NAMESPACE>"
+						namespace = "` + testAccNamespace + `"
 						name      = "test-terraform-1"
 						type      = "protected"
 
@@ -55,6 +63,11 @@ func TestAccEndpointsResource(t *testing.T) {
 					resource.TestCheckResourceAttr("huggingface_endpoint.test", "cloud_provider.region", "us-east-1"),
 					resource.TestCheckResourceAttr("huggingface_endpoint.test", "compute.scaling.min_replica", "0"),
 					resource.TestCheckResourceAttr("huggingface_endpoint.test", "compute.scaling.max_replica", "1"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "id", testAccNamespace+"/test-terraform-1"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "running"),
+					resource.TestCheckResourceAttrSet("huggingface_endpoint.test", "status.url"),
+					resource.TestCheckResourceAttrSet("huggingface_endpoint.test", "estimated_hourly_cost_usd"),
+					resource.TestCheckResourceAttrSet("huggingface_endpoint.test", "estimated_max_monthly_cost_usd"),
 				),
 			},
 			// ImportState testing
@@ -62,15 +75,14 @@ func TestAccEndpointsResource(t *testing.T) {
 				ResourceName:      "huggingface_endpoint.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// The last_updated attribute does not exist in the HashiCups
-				// API, therefore there is no value for it during import.
-				ImportStateVerifyIgnore: []string{"status"},
+				// status moves on between reads and timeouts is configuration only
+				ImportStateVerifyIgnore: []string{"status", "timeouts"},
 			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
 					resource "huggingface_endpoint" "test" {
-						namespace = "<YOUR_NAMESPACE>"
+						namespace = "` + testAccNamespace + `"
 						name      = "test-terraform-1"
 						type      = "protected"
 
@@ -116,3 +128,803 @@ func TestAccEndpointsResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccEndpointsResource_replace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEndpointResourceReplaceConfig("test-terraform-replace", "us-east-1"),
+			},
+			// Moving the endpoint to another region replaces it
+			{
+				Config: testAccEndpointResourceReplaceConfig("test-terraform-replace", "eu-west-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "cloud_provider.region", "eu-west-1"),
+				),
+			},
+			// Renaming the endpoint replaces it
+			{
+				Config: testAccEndpointResourceReplaceConfig("test-terraform-renamed", "eu-west-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "id", testAccNamespace+"/test-terraform-renamed"),
+				),
+			},
+		},
+	})
+}
+
+// testAccEndpointResourceReplaceConfig returns a minimal endpoint configuration
+// with the given name and region.
+func testAccEndpointResourceReplaceConfig(name, region string) string {
+	return providerConfig + fmt.Sprintf(`
+		resource "huggingface_endpoint" "test" {
+			namespace = %[1]q
+			name      = %[2]q
+			type      = "protected"
+
+			compute = {
+				accelerator   = "cpu"
+				instance_type = "intel-icl"
+				instance_size = "x4"
+				scaling = {
+					min_replica = 0
+					max_replica = 1
+
+					measure = {
+						hardware_usage = 10
+					}
+				}
+			}
+
+			model = {
+				framework  = "pytorch"
+				repository = "openai-community/gpt2"
+				task       = "text-generation"
+				image = {
+					huggingface = {}
+				}
+			}
+
+			cloud_provider = {
+				region = %[3]q
+				vendor = "aws"
+			}
+		}
+	`, testAccNamespace, name, region)
+}
+
+func TestAccEndpointsResource_invalidConfig(t *testing.T) {
+	const validScaling = `
+		min_replica = 0
+		max_replica = 1
+
+		measure = {
+			hardware_usage = 10
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// No image variant
+			{
+				Config:      testAccEndpointResourceConfig(validScaling, ``),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+			// Several image variants
+			{
+				Config: testAccEndpointResourceConfig(validScaling, `
+					huggingface = {}
+					tgi = {
+						url = "ghcr.io/huggingface/text-generation-inference:latest"
+					}
+				`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// min_replica above max_replica
+			{
+				Config: testAccEndpointResourceConfig(`
+					min_replica = 2
+					max_replica = 1
+
+					measure = {
+						hardware_usage = 10
+					}
+				`, `huggingface = {}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Replica Range`),
+			},
+			// Negative scale_to_zero_timeout
+			{
+				Config: testAccEndpointResourceConfig(validScaling+`
+					scale_to_zero_timeout = -1
+				`, `huggingface = {}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be at least 0`),
+			},
+			// Out of range threshold
+			{
+				Config: testAccEndpointResourceConfig(validScaling+`
+					threshold = 150
+				`, `huggingface = {}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`threshold value must be between`),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_invalidCompute(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccEndpointResourceReplaceConfig("test-terraform-invalid-compute", "mars-north-1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Cloud Provider Region`),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_budget(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "huggingface" {
+						hf_token              = "<YOUR_HF_TOKEN>"
+						max_hourly_budget_usd = 0.0001
+					}
+				` + strings.TrimPrefix(testAccEndpointResourceReplaceConfig("test-terraform-budget", "us-east-1"), providerConfig),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Hourly Budget Exceeded`),
+			},
+		},
+	})
+}
+
+// testAccEndpointResourceConfig returns an endpoint configuration with the
+// given compute.scaling and model.image attributes.
+func testAccEndpointResourceConfig(scaling, image string) string {
+	return providerConfig + `
+		resource "huggingface_endpoint" "test" {
+			namespace = "` + testAccNamespace + `"
+			name      = "test-terraform-invalid"
+			type      = "protected"
+
+			compute = {
+				accelerator   = "cpu"
+				instance_type = "intel-icl"
+				instance_size = "x4"
+				scaling = {
+					` + scaling + `
+				}
+			}
+
+			model = {
+				framework  = "pytorch"
+				repository = "openai-community/gpt2"
+				task       = "text-generation"
+				image = {
+					` + image + `
+				}
+			}
+
+			cloud_provider = {
+				region = "us-east-1"
+				vendor = "aws"
+			}
+		}
+	`
+}
+
+func TestAccEndpointsResource_providerDefaults(t *testing.T) {
+	providerDefaults := `
+		provider "huggingface" {
+			hf_token          = "<YOUR_HF_TOKEN>"
+			default_namespace = "` + testAccNamespace + `"
+			default_tags      = ["managed-by:terraform"]
+			default_tags_map = {
+				team = "ml"
+			}
+		}
+	`
+	config := func(tags string) string {
+		endpoint := strings.TrimPrefix(testAccEndpointResourceReplaceConfig("test-terraform-defaults", "us-east-1"), providerConfig)
+		endpoint = strings.Replace(endpoint, `namespace = "`+testAccNamespace+`"`, tags, 1)
+		return providerDefaults + endpoint
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`tags = ["gpt2"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "namespace", testAccNamespace),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags.0", "gpt2"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags_all.#", "3"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags_all.0", "gpt2"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags_all.1", "managed-by:terraform"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags_all.2", "team:ml"),
+				),
+			},
+			// Refreshing the default tags shows no diff
+			{
+				Config: config(`tags = ["gpt2"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// A default tag also set on the resource is kept in tags
+			{
+				Config: config(`tags = ["gpt2", "team:ml"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "tags_all.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_defaultNamespaceChange(t *testing.T) {
+	config := func(defaultNamespace string) string {
+		endpoint := strings.TrimPrefix(testAccEndpointResourceReplaceConfig("test-terraform-default-namespace", "us-east-1"), providerConfig)
+		endpoint = strings.Replace(endpoint, `namespace = "`+testAccNamespace+`"`, "", 1)
+		return `
+			provider "huggingface" {
+				hf_token          = "<YOUR_HF_TOKEN>"
+				default_namespace = "` + defaultNamespace + `"
+			}
+		` + endpoint
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(testAccNamespace),
+				Check:  resource.TestCheckResourceAttr("huggingface_endpoint.test", "namespace", testAccNamespace),
+			},
+			// The endpoint follows the new default namespace
+			{
+				Config: config(testAccNamespace + "-other"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "namespace", testAccNamespace+"-other"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "id", testAccNamespace+"-other/test-terraform-default-namespace"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_missingNamespace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(
+					testAccEndpointResourceReplaceConfig("test-terraform-no-namespace", "us-east-1"),
+					`namespace = "`+testAccNamespace+`"`, "", 1,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Endpoint Namespace`),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_envAndSecrets(t *testing.T) {
+	config := func(apiKey string) string {
+		return strings.Replace(
+			testAccEndpointResourceReplaceConfig("test-terraform-env", "us-east-1"),
+			`task       = "text-generation"`,
+			`task       = "text-generation"
+				env = {
+					HF_HUB_ENABLE_HF_TRANSFER = "1"
+					MAX_CONCURRENT_REQUESTS   = "64"
+				}
+				secrets = {
+					API_KEY = "`+apiKey+`"
+				}`,
+			1,
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("s3cr3t"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.env.HF_HUB_ENABLE_HF_TRANSFER", "1"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.env.MAX_CONCURRENT_REQUESTS", "64"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.secrets.API_KEY", "s3cr3t"),
+				),
+			},
+			// Secrets are kept on refresh although the API does not return them
+			{
+				Config: config("s3cr3t"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Rotating a secret updates the endpoint in place
+			{
+				Config: config("r0tated"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.secrets.API_KEY", "r0tated"),
+			},
+			{
+				ResourceName:      "huggingface_endpoint.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// secrets are never returned by the API
+				ImportStateVerifyIgnore: []string{"status", "timeouts", "model.secrets"},
+			},
+			// Removing the env and secrets clears them on the endpoint
+			{
+				Config: testAccEndpointResourceReplaceConfig("test-terraform-env", "us-east-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.env.%", "0"),
+					resource.TestCheckNoResourceAttr("huggingface_endpoint.test", "model.secrets.%"),
+					func(*terraform.State) error {
+						if testAccServer == nil {
+							return nil
+						}
+						endpoint, _ := testAccServer.Endpoint(testAccNamespace, "test-terraform-env")
+						if len(endpoint.Model.Env) > 0 || len(endpoint.Model.Secrets) > 0 {
+							return fmt.Errorf("expected the env and secrets to be cleared, got %v and %v", endpoint.Model.Env, endpoint.Model.Secrets)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_trackRevision(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("moving the branch head is only possible on the fake API")
+	}
+
+	config := strings.Replace(
+		testAccEndpointResourceReplaceConfig("test-terraform-revision", "us-east-1"),
+		`repository = "openai-community/gpt2"`,
+		`repository     = "openai-community/gpt2"
+				revision       = "main"
+				track_revision = true`,
+		1,
+	)
+	pushed := strings.Repeat("b", 40)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.revision", "main"),
+					resource.TestMatchResourceAttr("huggingface_endpoint.test", "model.resolved_sha", regexp.MustCompile(`^[0-9a-f]{40}$`)),
+				),
+			},
+			// An unchanged branch head plans nothing
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// A moved branch head rolls out the new commit
+			{
+				PreConfig: func() {
+					testAccServer.SetModelRevision("openai-community/gpt2", "main", pushed)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.revision", "main"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.resolved_sha", pushed),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_writeOnlyCredentials(t *testing.T) {
+	config := func(password string, version int) string {
+		return strings.NewReplacer(
+			`task       = "text-generation"`,
+			fmt.Sprintf(`task       = "text-generation"
+				secrets_wo = {
+					API_KEY = %[1]q
+				}
+				secrets_wo_version = %[2]d`, password, version),
+			`huggingface = {}`,
+			fmt.Sprintf(`custom = {
+						url = "registry.example.com/gpt2:latest"
+						credentials = {
+							username            = "robot"
+							password_wo         = %[1]q
+							password_wo_version = %[2]d
+						}
+					}`, password, version),
+		).Replace(testAccEndpointResourceReplaceConfig("test-terraform-write-only", "us-east-1"))
+	}
+
+	expectRegistryPassword := func(expected string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if testAccServer == nil {
+				return nil
+			}
+			if password, _ := testAccServer.RegistryPassword(testAccNamespace, "test-terraform-write-only"); password != expected {
+				return fmt.Errorf("expected the API to receive the registry password %q, got %q", expected, password)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("s3cr3t", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.image.custom.credentials.username", "robot"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.image.custom.credentials.password_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("huggingface_endpoint.test", "model.image.custom.credentials.password_wo"),
+					resource.TestCheckNoResourceAttr("huggingface_endpoint.test", "model.image.custom.credentials.password"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.secrets_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("huggingface_endpoint.test", "model.secrets_wo.%"),
+					expectRegistryPassword("s3cr3t"),
+				),
+			},
+			// A changed write-only value alone is not detected
+			{
+				Config: config("r0tated", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Bumping the versions sends the rotated values
+			{
+				Config: config("r0tated", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "model.image.custom.credentials.password_wo_version", "2"),
+					expectRegistryPassword("r0tated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_conflictingPasswords(t *testing.T) {
+	config := strings.Replace(
+		testAccEndpointResourceReplaceConfig("test-terraform-write-only", "us-east-1"),
+		`huggingface = {}`,
+		`custom = {
+						url = "registry.example.com/gpt2:latest"
+						credentials = {
+							username    = "robot"
+							password    = "s3cr3t"
+							password_wo = "s3cr3t"
+						}
+					}`,
+		1,
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_desiredState(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("changing the endpoint state outside of Terraform is only possible on the fake API")
+	}
+
+	const name = "test-terraform-desired-state"
+	config := func(desiredState string) string {
+		return strings.Replace(
+			testAccEndpointResourceReplaceConfig(name, "us-east-1"),
+			`type      = "protected"`,
+			`type      = "protected"
+			desired_state = "`+desiredState+`"`,
+			1,
+		)
+	}
+
+	// Pausing or resuming alone must not redeploy the endpoint
+	var updatedAt time.Time
+	rememberUpdatedAt := func() {
+		endpoint, _ := testAccServer.Endpoint(testAccNamespace, name)
+		updatedAt = endpoint.Status.UpdatedAt
+	}
+	expectNotRedeployed := func(*terraform.State) error {
+		if endpoint, _ := testAccServer.Endpoint(testAccNamespace, name); !endpoint.Status.UpdatedAt.Equal(updatedAt) {
+			return fmt.Errorf("expected the endpoint not to be updated, updated at %s", endpoint.Status.UpdatedAt)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("running"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "desired_state", "running"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "running"),
+				),
+			},
+			{
+				PreConfig: rememberUpdatedAt,
+				Config:    config("paused"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "desired_state", "paused"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "paused"),
+					expectNotRedeployed,
+				),
+			},
+			{
+				PreConfig: rememberUpdatedAt,
+				Config:    config("running"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "desired_state", "running"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "running"),
+					expectNotRedeployed,
+				),
+			},
+			{
+				Config: config("scaled_to_zero"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "desired_state", "scaled_to_zero"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "scaledToZero"),
+				),
+			},
+			// Waking up on traffic is not a drift
+			{
+				PreConfig: func() {
+					testAccServer.SetState(testAccNamespace, name, huggingface.StateRunning, "")
+				},
+				Config: config("scaled_to_zero"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Pausing the endpoint outside of Terraform is reported by Read
+			{
+				PreConfig: func() {
+					testAccServer.SetState(testAccNamespace, name, huggingface.StatePaused, "")
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "desired_state", "paused"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "paused"),
+				),
+			},
+			// and reverted by the next apply
+			{
+				Config: config("scaled_to_zero"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "desired_state", "scaled_to_zero"),
+					resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "scaledToZero"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_failedDeployment(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("failing a deployment is only possible on the fake API")
+	}
+
+	const name = "test-terraform-failed"
+	config := testAccEndpointResourceReplaceConfig(name, "us-east-1")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The error message of the endpoint is reported
+			{
+				PreConfig: func() {
+					testAccServer.FailEndpoint(testAccNamespace, name, "CUDA out of memory")
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`CUDA\s+out\s+of\s+memory`),
+			},
+			// The failed endpoint was saved as tainted rather than orphaned, so
+			// it is replaced instead of conflicting with a new creation
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "running"),
+			},
+			// A failed update is reported the same way
+			{
+				PreConfig: func() {
+					testAccServer.FailEndpoint(testAccNamespace, name, "image not found")
+				},
+				Config:      strings.Replace(config, "max_replica = 1", "max_replica = 2", 1),
+				ExpectError: regexp.MustCompile(`image\s+not\s+found`),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_waitTimeout(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("a millisecond timeout is only reliably exceeded on the fake API")
+	}
+
+	config := testAccEndpointResourceReplaceConfig("test-terraform-timeout", "us-east-1")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(config, `cloud_provider = {`, `timeouts {
+				create = "1ms"
+			}
+
+			cloud_provider = {`, 1),
+				ExpectError: regexp.MustCompile(`timeout\s+while\s+waiting\s+for\s+endpoint`),
+			},
+			// The endpoint still being created was saved as tainted
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "running"),
+			},
+		},
+	})
+}
+
+func TestAccEndpointsResource_removedOutOfBand(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("deleting the endpoint or revoking the token mid-test is only possible on the fake API")
+	}
+
+	const name = "test-terraform-removed"
+	// The token is only rejected by the endpoint reads
+	config := strings.Replace(
+		testAccEndpointResourceReplaceConfig(name, "us-east-1"),
+		`hf_token = "<YOUR_HF_TOKEN>"`,
+		`hf_token              = "<YOUR_HF_TOKEN>"
+			skip_token_validation = true`,
+		1,
+	)
+	t.Cleanup(func() {
+		testAccServer.Token = ""
+		testAccServer.ForbiddenNamespace = ""
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// An endpoint deleted outside of Terraform is recreated
+			{
+				PreConfig: func() {
+					testAccServer.RemoveEndpoint(testAccNamespace, name)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("huggingface_endpoint.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("huggingface_endpoint.test", "status.state", "running"),
+			},
+			// A rejected token fails the refresh rather than dropping the endpoint
+			{
+				PreConfig: func() {
+					testAccServer.Token = "hf_other"
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Unauthorized to Read Huggingface Endpoint`),
+			},
+			// So does a token without access to the namespace
+			{
+				PreConfig: func() {
+					testAccServer.Token = ""
+					testAccServer.ForbiddenNamespace = testAccNamespace
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Unauthorized to Read Huggingface Endpoint`),
+			},
+			// The endpoint was kept in state throughout
+			{
+				PreConfig: func() {
+					testAccServer.ForbiddenNamespace = ""
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
@@ -3,10 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
 	"github.com/sebps/terraform-provider-huggingface/internal/states"
 	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
 )
@@ -21,6 +26,20 @@ func (r *endpointsResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state states.EndpointResourceState
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var id, name, namespace string
 	if !plan.ID.IsNull() && !plan.ID.IsUnknown() {
 		// extract id
@@ -38,37 +57,144 @@ func (r *endpointsResource) Update(ctx context.Context, req resource.UpdateReque
 		namespace = plan.Namespace.ValueString()
 	}
 
-	// Define endpoint to create from plan
-	endpointToUpdate := transformers.FromPlanToEndpointUpdate(ctx, &plan)
-
-	// Update endpoint
-	endpointUpdated, err := r.client.UpdateEndpoint(namespace, name, endpointToUpdate)
+	onlyDesiredState, err := onlyDesiredStateChanged(req.Plan.Raw, req.State.Raw)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error updating endpoint",
-			"Could not update endpoint, unexpected error: "+err.Error(),
+			"Error comparing plan and state",
+			"Could not compare the plan of the endpoint with its state, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
-	// Map back plan from created endpoint
-	updatedPlan, diags := transformers.FromProviderToModel(ctx, endpointUpdated)
+	var (
+		endpointReady *huggingface.EndpointWithStatus
+		waitErr       error
+	)
+	if onlyDesiredState {
+		// Nothing to roll out, only the desired state is applied
+		endpointReady, err = r.client.GetEndpoint(namespace, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading endpoint",
+				"Could not read endpoint "+namespace+"/"+name+": "+err.Error(),
+			)
+			return
+		}
+	} else {
+		endpointReady, waitErr = r.updateEndpoint(ctx, req, &plan, &state, namespace, name, updateTimeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Pause, resume or scale the endpoint to zero when requested
+	if waitErr == nil {
+		endpointReady, waitErr = r.applyDesiredState(ctx, namespace, name, plan.DesiredState, !plan.DesiredState.Equal(state.DesiredState), endpointReady, updateTimeout)
+	}
+
+	// Map back plan from updated endpoint
+	endpointState, diags := transformers.FromProviderToModel(ctx, endpointReady)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// inject namespace
-	updatedPlan.Namespace = types.StringValue(namespace)
+	endpointState.Namespace = types.StringValue(namespace)
+
+	// keep the secrets and revision settings the API does not return
+	resp.Diagnostics.Append(keepModelConfiguration(&endpointState, plan.Endpoint)...)
+
+	// keep the provider default tags out of tags
+	tagsAll, diags := r.splitDefaultTags(ctx, &endpointState, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// inject id
 	id = fmt.Sprintf("%s/%s", namespace, name)
-	updatedPlan.ID = types.StringValue(id)
+	endpointState.ID = types.StringValue(id)
 
 	// Set state to fully populated data
+	updatedPlan := states.EndpointResourceState{
+		Endpoint:                   endpointState,
+		TagsAll:                    tagsAll,
+		DesiredState:               plan.DesiredState,
+		EstimatedHourlyCostUSD:     knownOrNull(plan.EstimatedHourlyCostUSD),
+		EstimatedMaxMonthlyCostUSD: knownOrNull(plan.EstimatedMaxMonthlyCostUSD),
+		Timeouts:                   plan.Timeouts,
+	}
 	diags = resp.State.Set(ctx, updatedPlan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for endpoint update",
+			"Endpoint "+id+" was updated but did not become ready: "+waitErr.Error(),
+		)
+		return
+	}
+}
+
+// updateEndpoint sends the plan to the API and waits for the update to be
+// rolled out. The last observed endpoint is returned alongside any wait
+// error, diagnostics being reserved to the failures leaving nothing to save.
+func (r *endpointsResource) updateEndpoint(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	plan, state *states.EndpointResourceState,
+	namespace, name string,
+	timeout time.Duration,
+	diags *diag.Diagnostics,
+) (*huggingface.EndpointWithStatus, error) {
+	// Define endpoint to update from plan
+	endpointToUpdate, d := transformers.FromPlanToEndpointUpdate(ctx, plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
+	}
+
+	// the model is left out of the update while unknown
+	if endpointToUpdate.Model != nil {
+		// send the write-only secrets and registry password of the configuration
+		diags.Append(setWriteOnlyModel(ctx, req.Config, &endpointToUpdate.Model.Secrets, endpointToUpdate.Model.Image)...)
+
+		// clear the secrets removed from the configuration
+		clearRemovedSecrets(state.Endpoint, endpointToUpdate.Model)
+	}
+
+	// send the provider default tags along with the endpoint tags
+	diags.Append(plan.TagsAll.ElementsAs(ctx, &endpointToUpdate.Tags, false)...)
+	if diags.HasError() {
+		return nil, nil
+	}
+
+	// Update endpoint
+	endpointUpdated, err := api.UpdateEndpoint(ctx, r.client, namespace, name, endpointToUpdate)
+	if err != nil {
+		diags.AddError(
+			"Error updating endpoint",
+			"Could not update endpoint, unexpected error: "+err.Error(),
+		)
+		return nil, nil
+	}
+
+	// Wait for the update to be rolled out, a paused endpoint stays paused
+	var endpointReady *huggingface.EndpointWithStatus
+	var waitErr error
+	if currentState, ok := state.Status.Attributes()["state"].(types.String); ok && currentState.ValueString() == string(huggingface.StatePaused) {
+		targets := append(slices.Clone(endpointReadyStates), huggingface.StatePaused)
+		endpointReady, waitErr = r.waitForEndpointState(ctx, namespace, name, targets, timeout)
+	} else {
+		endpointReady, waitErr = r.waitForEndpointRollout(ctx, namespace, name, endpointReadyStates, timeout)
+	}
+	if endpointReady == nil {
+		endpointReady = endpointUpdated
+	}
+
+	return endpointReady, waitErr
 }
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+	"github.com/sebps/terraform-provider-huggingface/internal/states"
+	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
+)
+
+func TestUpdateEndpointUnknownModel(t *testing.T) {
+	ctx := context.Background()
+	r, server := newTestEndpointsResource(t)
+	server.SeedEndpoint(testAccNamespace, testAccEndpoint("update"))
+
+	endpoint, err := r.client.GetEndpoint(testAccNamespace, "update")
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, diags := transformers.FromProviderToModel(ctx, endpoint)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	state := states.EndpointResourceState{Endpoint: read, TagsAll: types.ListValueMust(types.StringType, nil)}
+	plan := state
+	plan.Model = types.ObjectUnknown(models.Model{}.AttributeTypes())
+
+	// The model is left out of the update instead of being dereferenced
+	var updateDiags diag.Diagnostics
+	updated, err := r.updateEndpoint(ctx, resource.UpdateRequest{}, &plan, &state, testAccNamespace, "update", time.Second, &updateDiags)
+	if updateDiags.HasError() || err != nil {
+		t.Fatalf("unexpected error: %v %v", updateDiags, err)
+	}
+	if updated == nil || updated.Status.State != huggingface.StateRunning {
+		t.Fatalf("expected the updated endpoint to be running, got %+v", updated)
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// imageVariants lists the mutually exclusive model.image attributes.
+var imageVariants = []string{
+	"huggingface",
+	"huggingface_neuron",
+	"tgi",
+	"tgi_neuron",
+	"tei",
+	"llamacpp",
+	"custom",
+}
+
+var scalingPath = path.Root("compute").AtName("scaling")
+
+// ConfigValidators returns the cross-attribute validations run at plan time.
+func (r *endpointsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	imageExpressions := make([]path.Expression, 0, len(imageVariants))
+	for _, variant := range imageVariants {
+		imageExpressions = append(imageExpressions, path.MatchRoot("model").AtName("image").AtName(variant))
+	}
+
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(imageExpressions...),
+		replicaRangeValidator{},
+	}
+}
+
+// replicaRangeValidator ensures compute.scaling.min_replica does not exceed
+// compute.scaling.max_replica.
+type replicaRangeValidator struct{}
+
+var _ resource.ConfigValidator = replicaRangeValidator{}
+
+func (v replicaRangeValidator) Description(_ context.Context) string {
+	return "compute.scaling.min_replica must be less than or equal to compute.scaling.max_replica"
+}
+
+func (v replicaRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return "`compute.scaling.min_replica` must be less than or equal to `compute.scaling.max_replica`"
+}
+
+func (v replicaRangeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var minReplica, maxReplica types.Int32
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scalingPath.AtName("min_replica"), &minReplica)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scalingPath.AtName("max_replica"), &maxReplica)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values coming from unknown variables are checked once they are known
+	if minReplica.IsNull() || minReplica.IsUnknown() || maxReplica.IsNull() || maxReplica.IsUnknown() {
+		return
+	}
+
+	if minReplica.ValueInt32() > maxReplica.ValueInt32() {
+		resp.Diagnostics.AddAttributeError(
+			scalingPath.AtName("min_replica"),
+			"Invalid Replica Range",
+			fmt.Sprintf(
+				"min_replica (%d) must be less than or equal to max_replica (%d).",
+				minReplica.ValueInt32(),
+				maxReplica.ValueInt32(),
+			),
+		)
+	}
+}
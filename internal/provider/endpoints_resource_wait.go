@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+)
+
+const (
+	defaultCreateTimeout = 30 * time.Minute
+	defaultUpdateTimeout = 30 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// endpointPollInterval is the delay between two GetEndpoint calls while waiting.
+var endpointPollInterval = 10 * time.Second
+
+// endpointRolloutPolls is the number of polls an updated endpoint may keep
+// reporting a target state before the update is considered rolled out, in
+// case the API never starts a rollout for it.
+var endpointRolloutPolls = 3
+
+var (
+	// endpointPendingStates are transitional states an endpoint moves through
+	// before it settles.
+	endpointPendingStates = []huggingface.EndpointState{
+		huggingface.StatePending,
+		huggingface.StateInitializing,
+		huggingface.StateUpdating,
+	}
+
+	// endpointFailedStates are terminal states reported as errors.
+	endpointFailedStates = []huggingface.EndpointState{
+		huggingface.StateFailed,
+		huggingface.StateUpdateFailed,
+	}
+
+	// endpointReadyStates are the states Create and Update wait for. An
+	// endpoint with min_replica = 0 may settle directly as scaled to zero.
+	endpointReadyStates = []huggingface.EndpointState{
+		huggingface.StateRunning,
+		huggingface.StateScaledToZero,
+	}
+)
+
+// waitForEndpointState polls the endpoint until it reaches one of the target
+// states, lands in a failed state or the timeout expires. The last observed
+// endpoint is returned alongside any error so callers can still persist it.
+func (r *endpointsResource) waitForEndpointState(
+	ctx context.Context,
+	namespace, name string,
+	targets []huggingface.EndpointState,
+	timeout time.Duration,
+) (*huggingface.EndpointWithStatus, error) {
+	return r.pollEndpointState(ctx, namespace, name, targets, 0, timeout)
+}
+
+// waitForEndpointRollout waits for an update to be rolled out. The API keeps
+// reporting the previous state until the rollout starts, so a target state is
+// only accepted once the endpoint left it, or after endpointRolloutPolls polls.
+func (r *endpointsResource) waitForEndpointRollout(
+	ctx context.Context,
+	namespace, name string,
+	targets []huggingface.EndpointState,
+	timeout time.Duration,
+) (*huggingface.EndpointWithStatus, error) {
+	return r.pollEndpointState(ctx, namespace, name, targets, endpointRolloutPolls, timeout)
+}
+
+// pollEndpointState implements waitForEndpointState, only accepting a target
+// state seen in the first rolloutPolls polls once the endpoint left it.
+// Retryable errors are logged and polled again until the timeout expires.
+func (r *endpointsResource) pollEndpointState(
+	ctx context.Context,
+	namespace, name string,
+	targets []huggingface.EndpointState,
+	rolloutPolls int,
+	timeout time.Duration,
+) (*huggingface.EndpointWithStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(endpointPollInterval)
+	defer ticker.Stop()
+
+	var endpoint *huggingface.EndpointWithStatus
+	var state huggingface.EndpointState
+	var lastErr error
+	rolloutStarted := rolloutPolls == 0
+	for polls := 1; ; polls++ {
+		current, err := r.client.GetEndpoint(namespace, name)
+		if err != nil {
+			if !api.IsRetryable(err) {
+				return endpoint, err
+			}
+			tflog.Warn(ctx, "Transient error while polling endpoint, retrying", map[string]any{"id": namespace + "/" + name, "error": err.Error()})
+		} else {
+			endpoint, state = current, current.Status.State
+			tflog.Debug(ctx, "Polled endpoint state", map[string]any{"id": namespace + "/" + name, "state": string(state)})
+
+			switch {
+			case slices.Contains(targets, state) && !rolloutStarted && polls <= rolloutPolls:
+				tflog.Debug(ctx, "Waiting for the rollout to start", map[string]any{"id": namespace + "/" + name, "state": string(state)})
+			case slices.Contains(targets, state):
+				return endpoint, nil
+			case slices.Contains(endpointFailedStates, state):
+				errorMessage := endpoint.Status.Message
+				if endpoint.Status.ErrorMessage != nil {
+					errorMessage = *endpoint.Status.ErrorMessage
+				}
+				return endpoint, fmt.Errorf("endpoint %s/%s entered state %q: %s", namespace, name, state, errorMessage)
+			case !slices.Contains(endpointPendingStates, state):
+				rolloutStarted = true
+				tflog.Debug(ctx, "Endpoint in unexpected state, still waiting", map[string]any{"state": string(state)})
+			default:
+				rolloutStarted = true
+			}
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return endpoint, fmt.Errorf("timeout while waiting for endpoint %s/%s to reach %v, last error: %w", namespace, name, targets, lastErr)
+			}
+			return endpoint, fmt.Errorf("timeout while waiting for endpoint %s/%s to reach %v, last state %q", namespace, name, targets, state)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForEndpointDeletion polls the endpoint until the API reports it as not
+// found or the timeout expires. Retryable errors are polled again.
+func (r *endpointsResource) waitForEndpointDeletion(
+	ctx context.Context,
+	namespace, name string,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(endpointPollInterval)
+	defer ticker.Stop()
+
+	var state huggingface.EndpointState
+	for {
+		endpoint, err := r.client.GetEndpoint(namespace, name)
+		switch {
+		case api.IsNotFound(err):
+			return nil
+		case api.IsRetryable(err):
+			tflog.Warn(ctx, "Transient error while polling endpoint, retrying", map[string]any{"id": namespace + "/" + name, "error": err.Error()})
+		case err != nil:
+			return err
+		default:
+			state = endpoint.Status.State
+			tflog.Debug(ctx, "Endpoint still being deleted", map[string]any{"id": namespace + "/" + name, "state": string(state)})
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("timeout while waiting for endpoint %s/%s to be deleted, last error: %w", namespace, name, err)
+			}
+			return fmt.Errorf("timeout while waiting for endpoint %s/%s to be deleted, last state %q", namespace, name, state)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+func TestWaitForEndpointState(t *testing.T) {
+	testCases := map[string]struct {
+		state         huggingface.EndpointState
+		errorMessage  string
+		targets       []huggingface.EndpointState
+		expectedState huggingface.EndpointState
+		expectedError string
+	}{
+		"settled": {
+			state:         huggingface.StateInitializing,
+			targets:       endpointReadyStates,
+			expectedState: huggingface.StateRunning,
+		},
+		"failed": {
+			state:         huggingface.StateFailed,
+			errorMessage:  "CUDA out of memory",
+			targets:       endpointReadyStates,
+			expectedState: huggingface.StateFailed,
+			expectedError: `entered state "failed": CUDA out of memory`,
+		},
+		"update failed": {
+			state:         huggingface.StateUpdateFailed,
+			errorMessage:  "image not found",
+			targets:       endpointReadyStates,
+			expectedState: huggingface.StateUpdateFailed,
+			expectedError: `entered state "updateFailed": image not found`,
+		},
+		"timeout": {
+			state:         huggingface.StateRunning,
+			targets:       []huggingface.EndpointState{huggingface.StatePaused},
+			expectedState: huggingface.StateRunning,
+			expectedError: `timeout while waiting for endpoint terraform-acc/wait to reach [paused], last state "running"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, server := newTestEndpointsResource(t)
+			server.SeedEndpoint(testAccNamespace, testAccEndpoint("wait"))
+			server.SetState(testAccNamespace, "wait", testCase.state, testCase.errorMessage)
+
+			endpoint, err := r.waitForEndpointState(context.Background(), testAccNamespace, "wait", testCase.targets, 50*time.Millisecond)
+			switch {
+			case testCase.expectedError == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case testCase.expectedError != "" && (err == nil || !strings.Contains(err.Error(), testCase.expectedError)):
+				t.Fatalf("expected an error containing %q, got: %v", testCase.expectedError, err)
+			}
+
+			// The last observed endpoint is returned for the state to be saved
+			if endpoint == nil || endpoint.Status.State != testCase.expectedState {
+				t.Fatalf("expected the last observed endpoint in state %q, got %+v", testCase.expectedState, endpoint)
+			}
+		})
+	}
+}
+
+func TestWaitForEndpointStateRetries(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode    int
+		expectedError string
+	}{
+		"service unavailable": {
+			statusCode: 503,
+		},
+		"rate limited": {
+			statusCode: 429,
+		},
+		"forbidden": {
+			statusCode:    403,
+			expectedError: "HTTP error 403",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, server := newTestEndpointsResource(t)
+			server.SeedEndpoint(testAccNamespace, testAccEndpoint("wait"))
+			server.FailReads(testAccNamespace, "wait", testCase.statusCode, 2)
+
+			endpoint, err := r.waitForEndpointState(context.Background(), testAccNamespace, "wait", endpointReadyStates, time.Second)
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Fatalf("expected an error containing %q, got: %v", testCase.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if endpoint == nil || endpoint.Status.State != huggingface.StateRunning {
+				t.Fatalf("expected the endpoint to be running, got %+v", endpoint)
+			}
+		})
+	}
+}
+
+func TestWaitForEndpointRollout(t *testing.T) {
+	r, server := newTestEndpointsResource(t)
+	server.SeedEndpoint(testAccNamespace, testAccEndpoint("rollout"))
+
+	// The endpoint still reports running right after the update
+	server.DelayRollout(testAccNamespace, "rollout", 1)
+	if _, err := r.client.UpdateEndpoint(testAccNamespace, "rollout", huggingface.EndpointUpdate{}); err != nil {
+		t.Fatal(err)
+	}
+
+	endpoint, err := r.waitForEndpointRollout(context.Background(), testAccNamespace, "rollout", endpointReadyStates, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if endpoint.Status.State != huggingface.StateRunning {
+		t.Fatalf("expected the endpoint to be running, got %q", endpoint.Status.State)
+	}
+	if stored, _ := server.Endpoint(testAccNamespace, "rollout"); stored.Status.State != huggingface.StateRunning {
+		t.Fatalf("expected the wait to outlast the rollout, the endpoint is still %q", stored.Status.State)
+	}
+
+	// An endpoint never leaving running settles after endpointRolloutPolls polls
+	endpoint, err = r.waitForEndpointRollout(context.Background(), testAccNamespace, "rollout", endpointReadyStates, time.Second)
+	if err != nil || endpoint.Status.State != huggingface.StateRunning {
+		t.Fatalf("expected the running endpoint to be accepted, got %+v (%v)", endpoint, err)
+	}
+}
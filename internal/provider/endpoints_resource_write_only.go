@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+)
+
+var (
+	secretsWOPath  = path.Root("model").AtName("secrets_wo")
+	passwordWOPath = path.Root("model").AtName("image").AtName("custom").AtName("credentials").AtName("password_wo")
+)
+
+// setWriteOnlyModel sets the secrets and the registry password of the model
+// sent to the API from the write-only attributes of the configuration, which
+// are never part of the plan. They are sent on every create and update so the
+// API never falls back to stale values.
+func setWriteOnlyModel(ctx context.Context, config tfsdk.Config, secrets *map[string]*string, image *huggingface.EndpointModelImage) diag.Diagnostics {
+	var secretsWO types.Map
+	var passwordWO types.String
+
+	var diags diag.Diagnostics
+	diags.Append(config.GetAttribute(ctx, secretsWOPath, &secretsWO)...)
+	diags.Append(config.GetAttribute(ctx, passwordWOPath, &passwordWO)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !secretsWO.IsNull() && !secretsWO.IsUnknown() {
+		var values map[string]string
+		diags.Append(secretsWO.ElementsAs(ctx, &values, false)...)
+
+		*secrets = make(map[string]*string, len(values))
+		for name, value := range values {
+			(*secrets)[name] = &value
+		}
+	}
+
+	if !passwordWO.IsNull() && !passwordWO.IsUnknown() && image != nil && image.Custom != nil && image.Custom.Credentials != nil {
+		image.Custom.Credentials.Password = passwordWO.ValueStringPointer()
+	}
+
+	return diags
+}
+
+// clearRemovedSecrets sends an empty map of secrets when the plan sets none but
+// the prior state did, in secrets or secrets_wo, as secrets left out of an
+// update are kept on the endpoint.
+func clearRemovedSecrets(state models.Endpoint, model *huggingface.EndpointModelUpdate) {
+	if model == nil || model.Secrets != nil || state.Model.IsNull() || state.Model.IsUnknown() {
+		return
+	}
+
+	attributes := state.Model.Attributes()
+	secrets, _ := attributes["secrets"].(types.Map)
+	secretsWOVersion, _ := attributes["secrets_wo_version"].(types.Int32)
+	if len(secrets.Elements()) > 0 || (!secretsWOVersion.IsNull() && !secretsWOVersion.IsUnknown()) {
+		model.Secrets = map[string]*string{}
+	}
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+	"github.com/sebps/terraform-provider-huggingface/internal/transformers"
+)
+
+func TestClearRemovedSecrets(t *testing.T) {
+	secret := "s3cr3t"
+
+	testCases := map[string]struct {
+		secrets          types.Map
+		secretsWOVersion types.Int32
+		planSecrets      map[string]*string
+		expected         map[string]*string
+	}{
+		"never set": {
+			secrets:          types.MapNull(types.StringType),
+			secretsWOVersion: types.Int32Null(),
+		},
+		"secrets removed": {
+			secrets:          types.MapValueMust(types.StringType, map[string]attr.Value{"API_KEY": types.StringValue(secret)}),
+			secretsWOVersion: types.Int32Null(),
+			expected:         map[string]*string{},
+		},
+		"write-only secrets removed": {
+			secrets:          types.MapNull(types.StringType),
+			secretsWOVersion: types.Int32Value(1),
+			expected:         map[string]*string{},
+		},
+		"secrets kept": {
+			secrets:          types.MapValueMust(types.StringType, map[string]attr.Value{"API_KEY": types.StringValue(secret)}),
+			secretsWOVersion: types.Int32Null(),
+			planSecrets:      map[string]*string{"API_KEY": &secret},
+			expected:         map[string]*string{"API_KEY": &secret},
+		},
+	}
+
+	endpoint := testAccEndpoint("secrets")
+	read, diags := transformers.FromProviderToModel(context.Background(), &huggingface.EndpointWithStatus{
+		Name:     endpoint.Name,
+		Type:     endpoint.Type,
+		Provider: endpoint.Provider,
+		Compute:  endpoint.Compute,
+		Model:    endpoint.Model,
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := withModel(t, read, func(model *models.Model) {
+				model.Secrets = testCase.secrets
+				model.SecretsWOVersion = testCase.secretsWOVersion
+			})
+			update := &huggingface.EndpointModelUpdate{Secrets: testCase.planSecrets}
+
+			clearRemovedSecrets(state, update)
+
+			if (update.Secrets == nil) != (testCase.expected == nil) || len(update.Secrets) != len(testCase.expected) {
+				t.Errorf("expected secrets %v, got %v", testCase.expected, update.Secrets)
+			}
+		})
+	}
+}
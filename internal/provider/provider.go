@@ -5,16 +5,27 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"slices"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+	"github.com/sebps/terraform-provider-huggingface/internal/credentials"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -50,10 +61,76 @@ func (p *huggingfaceProvider) Schema(_ context.Context, _ provider.SchemaRequest
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"hf_token": schema.StringAttribute{
-				Description: "Hugging face token from the Access Token section",
-				Optional:    false,
-				Sensitive:   true,
-				Required:    true,
+				Description: "Hugging face token from the Access Token section. " +
+					"Defaults to the HF_TOKEN environment variable, then to the file named by HF_TOKEN_PATH, then to $HF_HOME/token " +
+					"when HF_HOME is set or the ~/.cache/huggingface/token file written by huggingface-cli login otherwise.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"endpoint_url": schema.StringAttribute{
+				Description: "Base URL of the Inference Endpoints API, defaults to " + huggingface.HostURL + ". May also be provided via the HF_ENDPOINT_URL environment variable.",
+				Optional:    true,
+			},
+			"hub_url": schema.StringAttribute{
+				Description: "Base URL of the Hugging Face Hub answering the whoami API, defaults to " + api.DefaultHubURL + ". " +
+					"May also be provided via the HF_ENDPOINT environment variable, as for the huggingface_hub library.",
+				Optional: true,
+			},
+			"skip_token_validation": schema.BoolAttribute{
+				Description: "Skip the check of the token against the Hub whoami API when configuring the provider.",
+				Optional:    true,
+			},
+			"default_namespace": schema.StringAttribute{
+				Description: "Namespace of the huggingface_endpoint resources leaving namespace unset, also used when an endpoint is imported by its bare name.",
+				Optional:    true,
+			},
+			"default_tags": schema.ListAttribute{
+				Description: "Tags added to every huggingface_endpoint resource. The tags of a resource and the default tags are exposed together in its tags_all attribute.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"default_tags_map": schema.MapAttribute{
+				Description: "Tags added to every huggingface_endpoint resource given as a map, each entry becoming a \"key:value\" tag. Merged with default_tags.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"http_trace": schema.BoolAttribute{
+				Description: "Log every Inference Endpoints API call with its method, URL, status, latency and redacted bodies. " +
+					"Logs are written at debug level in the api subsystem, enabled with TF_LOG_PROVIDER_HUGGINGFACE_API=DEBUG.",
+				Optional: true,
+			},
+			"max_retries": schema.Int32Attribute{
+				Description: fmt.Sprintf("Number of retries of an API call failing with a transient error, defaults to %d. "+
+					"Rate limited calls (429) are always retried, network errors and 502, 503 and 504 responses only when the call is idempotent, "+
+					"so an endpoint creation is never sent twice. Set to 0 to disable retries.", api.DefaultMaxRetries),
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"retry_min_wait": schema.StringAttribute{
+				Description: "Minimum wait before retrying an API call, as a duration such as \"500ms\", defaults to " + api.DefaultRetryMinWait.String() + ". " +
+					"The wait doubles on every retry up to retry_max_wait, or follows the Retry-After header sent by the API, still capped by retry_max_wait.",
+				Optional: true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: "Maximum wait before retrying an API call, as a duration such as \"1m\", defaults to " + api.DefaultRetryMaxWait.String() + ".",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int32Attribute{
+				Description: "Maximum number of Inference Endpoints API calls in flight at once across every resource and data source. " +
+					"Unlimited by default, set it below the Terraform parallelism to avoid being rate limited.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"max_hourly_budget_usd": schema.Float64Attribute{
+				Description: "Maximum estimated hourly cost of a single endpoint at max_replica, in USD. Plans creating or changing an endpoint above this budget fail.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
 			},
 		},
 	}
@@ -79,7 +156,25 @@ func (p *huggingfaceProvider) Configure(ctx context.Context, req provider.Config
 			path.Root("hf_token"),
 			"Unknown Hugging Face Token",
 			"The provider cannot create the HuggingFace API client as there is an unknown configuration value for the Hugging Face Token. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the HASHICUPS_HOST environment variable.",
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the HF_TOKEN environment variable.",
+		)
+	}
+
+	if config.EndpointURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint_url"),
+			"Unknown Hugging Face Endpoint URL",
+			"The provider cannot create the HuggingFace API client as there is an unknown configuration value for the API base URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the HF_ENDPOINT_URL environment variable.",
+		)
+	}
+
+	if config.HubURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hub_url"),
+			"Unknown Hugging Face Hub URL",
+			"The provider cannot validate the Hugging Face Token as there is an unknown configuration value for the Hub base URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the HF_ENDPOINT environment variable.",
 		)
 	}
 
@@ -90,36 +185,79 @@ func (p *huggingfaceProvider) Configure(ctx context.Context, req provider.Config
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
-	hfToken := os.Getenv("HF_TOKEN")
+	endpointURL := os.Getenv("HF_ENDPOINT_URL")
 
-	if !config.HfToken.IsNull() {
-		hfToken = config.HfToken.ValueString()
+	if !config.EndpointURL.IsNull() {
+		endpointURL = config.EndpointURL.ValueString()
+	}
+
+	hubURL := os.Getenv("HF_ENDPOINT")
+
+	if !config.HubURL.IsNull() {
+		hubURL = config.HubURL.ValueString()
+	}
+
+	if hubURL == "" {
+		hubURL = api.DefaultHubURL
+	}
+
+	// The token falls back to the environment and the huggingface-cli files
+	hfToken, tokenSource, err := credentials.Resolve(config.HfToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hf_token"),
+			"Unable to Read Hugging Face Token",
+			"The provider cannot create the HuggingFace API client as the Hugging Face Token could not be read: "+err.Error(),
+		)
 	}
 
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if hfToken == "" {
+	if err == nil && hfToken == "" {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("host"),
+			path.Root("hf_token"),
 			"Missing Hugging Face Token",
 			"The provider cannot create the HuggingFace API client as there is a missing or empty value for the Hugging Face Token. "+
-				"Set the host value in the configuration or use the HF_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the hf_token value in the configuration, use the HF_TOKEN or HF_TOKEN_PATH environment variables, "+
+				"or log in with huggingface-cli login. If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	if endpointURL != "" {
+		if _, err := url.ParseRequestURI(endpointURL); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint_url"),
+				"Invalid Hugging Face Endpoint URL",
+				"The provider cannot create the HuggingFace API client as the API base URL is not a valid absolute URL: "+err.Error(),
+			)
+		}
+	}
+
+	if _, err := url.ParseRequestURI(hubURL); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hub_url"),
+			"Invalid Hugging Face Hub URL",
+			"The provider cannot validate the Hugging Face Token as the Hub base URL is not a valid absolute URL: "+err.Error(),
 		)
 	}
 
+	retryConfig, diags := newRetryConfig(config)
+	resp.Diagnostics.Append(diags...)
+
+	defaultTags, diags := newDefaultTags(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// logging
-	ctx = tflog.SetField(ctx, "huggingface_token", hfToken)
-	tflog.Debug(ctx, "Creating Huggingface client")
+	// logging, the token is masked from every log written from now on
+	ctx = api.NewLoggingContext(ctx, hfToken)
+	tflog.Debug(ctx, "Creating Huggingface client", map[string]any{"endpoint_url": endpointURL, "token_source": tokenSource})
 
 	// Create a new Hugging Face client using the configuration values
-	// h := "http://localhost:8080"
-	client, err := huggingface.NewClient(nil, &hfToken)
+	client, err := huggingface.NewClient(&endpointURL, &hfToken)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Hugging Face API Client",
@@ -130,10 +268,46 @@ func (p *huggingfaceProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
+	if config.HTTPTrace.ValueBool() {
+		client.Client.Transport = api.NewTracingTransport(ctx, client.Client.Transport)
+	}
+
+	// Every attempt gets the timeout of the client, which would otherwise
+	// be exhausted by the waits between retries. It starts once the attempt
+	// holds a slot of the limiter, so the wait for one does not count.
+	if client.Client.Timeout > 0 {
+		client.Client.Transport = api.NewTimeoutTransport(client.Client.Transport, client.Client.Timeout)
+		client.Client.Timeout = 0
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		client.Client.Transport = api.NewLimitTransport(client.Client.Transport, int(config.MaxConcurrentRequests.ValueInt32()))
+	}
+
+	client.Client.Transport = api.NewRetryTransport(ctx, client.Client.Transport, retryConfig)
+
+	whoami := api.NewWhoAmICache(client, hubURL)
+	if !config.SkipTokenValidation.ValueBool() {
+		resp.Diagnostics.Append(validateToken(ctx, whoami, tokenSource)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Make the HuggingFace client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &huggingfaceProviderData{
+		Client:           client,
+		DefaultNamespace: config.DefaultNamespace.ValueString(),
+		DefaultTags:      defaultTags,
+		Endpoints:        api.NewEndpointReader(client),
+		Catalog:          api.NewCatalogCache(client),
+		WhoAmI:           whoami,
+		HubURL:           hubURL,
+		MaxHourlyBudget:  config.MaxHourlyBudgetUSD.ValueFloat64Pointer(),
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 
 	tflog.Info(ctx, "Configured Huggingface client", map[string]any{"success": true})
 }
@@ -141,7 +315,10 @@ func (p *huggingfaceProvider) Configure(ctx context.Context, req provider.Config
 // DataSources defines the data sources implemented in the provider.
 func (p *huggingfaceProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewComputeCatalogDataSource,
+		NewEndpointDataSource,
 		NewEndpointsDataSource,
+		NewWhoAmIDataSource,
 	}
 }
 
@@ -154,5 +331,158 @@ func (p *huggingfaceProvider) Resources(_ context.Context) []func() resource.Res
 
 // hashicupsProviderModel maps provider schema data to a Go type.
 type hashicupsProviderModel struct {
-	HfToken types.String `tfsdk:"hf_token"`
+	HfToken               types.String  `tfsdk:"hf_token"`
+	EndpointURL           types.String  `tfsdk:"endpoint_url"`
+	HubURL                types.String  `tfsdk:"hub_url"`
+	SkipTokenValidation   types.Bool    `tfsdk:"skip_token_validation"`
+	DefaultNamespace      types.String  `tfsdk:"default_namespace"`
+	DefaultTags           types.List    `tfsdk:"default_tags"`
+	DefaultTagsMap        types.Map     `tfsdk:"default_tags_map"`
+	HTTPTrace             types.Bool    `tfsdk:"http_trace"`
+	MaxRetries            types.Int32   `tfsdk:"max_retries"`
+	RetryMinWait          types.String  `tfsdk:"retry_min_wait"`
+	RetryMaxWait          types.String  `tfsdk:"retry_max_wait"`
+	MaxConcurrentRequests types.Int32   `tfsdk:"max_concurrent_requests"`
+	MaxHourlyBudgetUSD    types.Float64 `tfsdk:"max_hourly_budget_usd"`
+}
+
+// huggingfaceProviderData is handed to data sources and resources at configure time.
+type huggingfaceProviderData struct {
+	Client           *huggingface.Client
+	DefaultNamespace string
+	// DefaultTags are merged into the tags of every endpoint resource.
+	DefaultTags []string
+	// Endpoints shares the in-flight reads of an endpoint across resources and data sources.
+	Endpoints *api.EndpointReader
+	// Catalog is shared by every resource so a plan lists the hardware catalog once.
+	Catalog *api.CatalogCache
+	// WhoAmI is the owner of the token, loaded once by the token validation.
+	WhoAmI *api.WhoAmICache
+	// HubURL is the Hub resolving model revisions.
+	HubURL string
+	// MaxHourlyBudget is nil when no budget is configured.
+	MaxHourlyBudget *float64
+}
+
+// newRetryConfig reads the retry settings of the provider configuration,
+// falling back to the api defaults.
+func newRetryConfig(config hashicupsProviderModel) (api.RetryConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	retryConfig := api.RetryConfig{
+		MaxRetries: api.DefaultMaxRetries,
+		MinWait:    api.DefaultRetryMinWait,
+		MaxWait:    api.DefaultRetryMaxWait,
+	}
+
+	if !config.MaxRetries.IsNull() {
+		retryConfig.MaxRetries = int(config.MaxRetries.ValueInt32())
+	}
+
+	parseWait := func(attribute string, value types.String, target *time.Duration) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+
+		wait, err := time.ParseDuration(value.ValueString())
+		if err != nil || wait < 0 {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Retry Wait",
+				fmt.Sprintf("%s must be a positive duration such as \"500ms\" or \"1m\", got %q.", attribute, value.ValueString()),
+			)
+			return
+		}
+		*target = wait
+	}
+	parseWait("retry_min_wait", config.RetryMinWait, &retryConfig.MinWait)
+	parseWait("retry_max_wait", config.RetryMaxWait, &retryConfig.MaxWait)
+
+	if !diags.HasError() && retryConfig.MinWait > retryConfig.MaxWait {
+		diags.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Invalid Retry Wait",
+			fmt.Sprintf("retry_min_wait (%s) must be less than or equal to retry_max_wait (%s).", retryConfig.MinWait, retryConfig.MaxWait),
+		)
+	}
+
+	return retryConfig, diags
+}
+
+// newDefaultTags merges the default_tags list with the "key:value" tags of
+// default_tags_map, sorted by key, dropping duplicates.
+func newDefaultTags(ctx context.Context, config hashicupsProviderModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.DefaultTags.IsUnknown() || config.DefaultTagsMap.IsUnknown() {
+		diags.AddError(
+			"Unknown Default Tags",
+			"The provider cannot merge the default tags into the endpoints as default_tags or default_tags_map is unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	var tags []string
+	diags.Append(config.DefaultTags.ElementsAs(ctx, &tags, false)...)
+
+	tagsMap := map[string]string{}
+	diags.Append(config.DefaultTagsMap.ElementsAs(ctx, &tagsMap, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(tagsMap)) {
+		tags = append(tags, key+":"+tagsMap[key])
+	}
+
+	return mergeTags(tags), diags
+}
+
+// validateToken checks the token against the Hub whoami API, so an invalid,
+// expired or insufficient token fails before the first endpoint call.
+func validateToken(ctx context.Context, whoami *api.WhoAmICache, tokenSource string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	identity, err := whoami.WhoAmI(ctx)
+	if api.IsUnauthorized(err) {
+		diags.AddAttributeError(
+			path.Root("hf_token"),
+			"Invalid Hugging Face Token",
+			fmt.Sprintf("The Hugging Face Token read from %s was rejected by the Hub, it is invalid, expired or revoked. "+
+				"Create a new token in the Access Tokens section of the Hugging Face settings. "+
+				"Set skip_token_validation to skip this check.\n\nHub Error: %s", tokenSource, err),
+		)
+		return diags
+	}
+	if err != nil {
+		// The Inference Endpoints API may still be reachable
+		diags.AddWarning(
+			"Unable to Validate Hugging Face Token",
+			"The Hugging Face Token could not be checked against the Hub whoami API, API calls may fail later on: "+err.Error(),
+		)
+		return diags
+	}
+
+	tflog.Debug(ctx, "Validated Huggingface token", map[string]any{"user": identity.Name, "role": identity.Role()})
+
+	if !identity.HasEndpointsAccess() {
+		diags.AddAttributeError(
+			path.Root("hf_token"),
+			"Insufficient Hugging Face Token Scope",
+			fmt.Sprintf("The fine-grained Hugging Face Token of %s has no Inference Endpoints permission. "+
+				"Grant it the inference.endpoints.write permission on the namespaces managed by Terraform, or use a write token.", identity.Name),
+		)
+		return diags
+	}
+
+	if identity.Role() == api.TokenRoleRead {
+		diags.AddAttributeWarning(
+			path.Root("hf_token"),
+			"Read-Only Hugging Face Token",
+			fmt.Sprintf("The Hugging Face Token of %s is a read token, endpoints can be read but creating, updating or deleting them will fail.", identity.Name),
+		)
+	}
+
+	return diags
 }
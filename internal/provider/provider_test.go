@@ -1,20 +1,33 @@
 package provider
 
 import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/testserver"
 )
 
 const (
 	// providerConfig is a shared configuration to combine with the actual
-	// test configuration so the HashiCups client is properly configured.
-	// It is also possible to use the HASHICUPS_ environment variables instead,
-	// such as updating the Makefile and running the testing through that tool.
+	// test configuration so the Huggingface client is properly configured.
+	// The API base URL is provided through the HF_ENDPOINT_URL environment
+	// variable, which points to the in-memory fake API unless already set,
+	// along with the HF_ENDPOINT Hub base URL.
 	providerConfig = `
 		provider "huggingface" {
 			hf_token = "<YOUR_HF_TOKEN>"
 		}
 	`
+
+	// testAccNamespace is the namespace endpoints are created in.
+	testAccNamespace = "terraform-acc"
 )
 
 var (
@@ -25,4 +38,175 @@ var (
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 		"huggingface": providerserver.NewProtocol6WithError(New("test")()),
 	}
+
+	// testAccServer is the fake Inference Endpoints API the acceptance tests
+	// run against. It is nil when HF_ENDPOINT_URL targets another API.
+	testAccServer *testserver.Server
 )
+
+func TestMain(m *testing.M) {
+	if os.Getenv("HF_ENDPOINT_URL") == "" {
+		testAccServer = testserver.New()
+		os.Setenv("HF_ENDPOINT_URL", testAccServer.URL)
+		os.Setenv("HF_ENDPOINT", testAccServer.URL)
+
+		// The fake API moves endpoints forward on every read
+		endpointPollInterval = 10 * time.Millisecond
+	}
+
+	code := m.Run()
+
+	if testAccServer != nil {
+		testAccServer.Close()
+	}
+
+	os.Exit(code)
+}
+
+func TestAccProvider_tokenFromCLICache(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("the huggingface-cli token is only known to the fake API")
+	}
+
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".cache", "huggingface"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".cache", "huggingface", "token"), []byte("hf_cli_token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("HOME", home)
+	t.Setenv("HF_TOKEN", "")
+	t.Setenv("HF_TOKEN_PATH", "")
+	t.Setenv("HF_HOME", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "huggingface" {}
+
+					data "huggingface_compute_catalog" "test" {}
+				`,
+				Check: resource.TestCheckResourceAttrSet("data.huggingface_compute_catalog.test", "computes.0.id"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_missingToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HF_TOKEN", "")
+	t.Setenv("HF_TOKEN_PATH", "")
+	t.Setenv("HF_HOME", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "huggingface" {}
+
+					data "huggingface_compute_catalog" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`Missing Hugging Face Token`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_invalidRetryWait(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "huggingface" {
+						hf_token       = "<YOUR_HF_TOKEN>"
+						retry_min_wait = "1m"
+						retry_max_wait = "10s"
+					}
+
+					data "huggingface_compute_catalog" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`must be less than or equal to retry_max_wait`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_invalidToken(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("the accepted token is only known to the fake API")
+	}
+
+	testAccServer.Token = "hf_expected"
+	t.Cleanup(func() { testAccServer.Token = "" })
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "huggingface" {
+						hf_token = "hf_expired"
+					}
+
+					data "huggingface_compute_catalog" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Hugging Face Token`),
+			},
+		},
+	})
+}
+
+// testAccSeedEndpoint creates a running endpoint in the fake API for the
+// duration of the test. It is a no-op against a real API, where the endpoint
+// is expected to exist already.
+func testAccSeedEndpoint(t *testing.T, name string) {
+	t.Helper()
+
+	if testAccServer == nil {
+		return
+	}
+
+	testAccServer.SeedEndpoint(testAccNamespace, testAccEndpoint(name))
+	t.Cleanup(func() {
+		testAccServer.RemoveEndpoint(testAccNamespace, name)
+	})
+}
+
+// testAccEndpoint returns a minimal cpu endpoint to seed the fake API with.
+func testAccEndpoint(name string) huggingface.Endpoint {
+	measure := 10.0
+	return huggingface.Endpoint{
+		Name: name,
+		Type: huggingface.TypeProtected,
+		Provider: huggingface.EndpointProvider{
+			Vendor: "aws",
+			Region: "us-east-1",
+		},
+		Compute: huggingface.EndpointCompute{
+			Accelerator:  huggingface.AcceleratorCPU,
+			InstanceType: "intel-icl",
+			InstanceSize: "x4",
+			Scaling: huggingface.EndpointScaling{
+				MinReplica: 0,
+				MaxReplica: 1,
+				Measure: &huggingface.ScalingMeasure{
+					HardwareUsage: &measure,
+				},
+			},
+		},
+		Model: huggingface.EndpointModel{
+			Repository: "openai-community/gpt2",
+			Framework:  huggingface.FrameworkPytorch,
+			Task:       "text-generation",
+			Image: huggingface.EndpointModelImage{
+				HuggingFace: &huggingface.HuggingFaceImage{},
+			},
+		},
+	}
+}
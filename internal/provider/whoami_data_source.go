@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &whoamiDataSource{}
+	_ datasource.DataSourceWithConfigure = &whoamiDataSource{}
+)
+
+func NewWhoAmIDataSource() datasource.DataSource {
+	return &whoamiDataSource{}
+}
+
+type whoamiDataSource struct {
+	whoami *api.WhoAmICache
+}
+
+func (d *whoamiDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_whoami"
+}
+
+func (d *whoamiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Describes the owner of the provider token and the token permissions, from the Hub whoami API.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Name of the token owner.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the token owner, usable as an endpoint namespace.",
+				Computed:    true,
+			},
+			"fullname": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the token owner, user or org.",
+				Computed:    true,
+			},
+			"orgs": schema.ListNestedAttribute{
+				Description: "Organizations the token owner belongs to.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Role of the token owner in the organization.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"token_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"token_role": schema.StringAttribute{
+				Description: "Role of the token, one of read, write or fineGrained.",
+				Computed:    true,
+			},
+			"token_global_permissions": schema.ListAttribute{
+				Description: "Permissions of a fine-grained token that are not tied to a user or an organization.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"token_scopes": schema.ListNestedAttribute{
+				Description: "Permissions of a fine-grained token on users and organizations.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.StringAttribute{
+							Computed: true,
+						},
+						"entity_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"permissions": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *whoamiDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*huggingfaceProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *huggingfaceProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.whoami = providerData.WhoAmI
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/states"
+	"github.com/sebps/terraform-provider-huggingface/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Read refreshes the Terraform state with the latest data.
+func (d *whoamiDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	whoami, err := d.whoami.WhoAmI(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Hugging Face Token Owner",
+			"Could not Read the owner of the Hugging Face Token from the Hub whoami API: "+err.Error(),
+		)
+		return
+	}
+
+	state := states.WhoAmIDataSourceState{
+		ID:                     types.StringValue(whoami.Name),
+		Name:                   types.StringValue(whoami.Name),
+		Fullname:               types.StringValue(whoami.Fullname),
+		Type:                   types.StringValue(whoami.Type),
+		Orgs:                   []states.WhoAmIOrg{},
+		TokenName:              types.StringNull(),
+		TokenRole:              types.StringNull(),
+		TokenGlobalPermissions: []types.String{},
+		TokenScopes:            []states.WhoAmIScope{},
+	}
+
+	for _, org := range whoami.Orgs {
+		state.Orgs = append(state.Orgs, states.WhoAmIOrg{
+			Name: types.StringValue(org.Name),
+			Role: types.StringValue(org.RoleInOrg),
+		})
+	}
+
+	if accessToken := whoami.Auth.AccessToken; accessToken != nil {
+		state.TokenName = types.StringValue(accessToken.DisplayName)
+		state.TokenRole = types.StringValue(accessToken.Role)
+
+		if fineGrained := accessToken.FineGrained; fineGrained != nil {
+			state.TokenGlobalPermissions = utils.ConvertStringSlice(fineGrained.Global)
+			for _, scope := range fineGrained.Scoped {
+				state.TokenScopes = append(state.TokenScopes, states.WhoAmIScope{
+					EntityType:  types.StringValue(scope.Entity.Type),
+					EntityName:  types.StringValue(scope.Entity.Name),
+					Permissions: utils.ConvertStringSlice(scope.Permissions),
+				})
+			}
+		}
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,41 @@
+package states
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ComputeCatalogDataSourceState maps the compute catalog data source schema data.
+type ComputeCatalogDataSourceState struct {
+	Filter   *ComputeCatalogDataSourceFilter `tfsdk:"filter"`
+	Computes []ComputeCatalogEntry           `tfsdk:"computes"`
+}
+
+// ComputeCatalogDataSourceFilter maps the optional filter narrowing down the listed instances.
+type ComputeCatalogDataSourceFilter struct {
+	Vendor          types.String  `tfsdk:"vendor"`
+	Region          types.String  `tfsdk:"region"`
+	Accelerator     types.String  `tfsdk:"accelerator"`
+	InstanceType    types.String  `tfsdk:"instance_type"`
+	MinAccelerators types.Int32   `tfsdk:"min_accelerators"`
+	MinMemoryGb     types.Int32   `tfsdk:"min_memory_gb"`
+	MinGpuMemoryGb  types.Int32   `tfsdk:"min_gpu_memory_gb"`
+	MaxPricePerHour types.Float64 `tfsdk:"max_price_per_hour"`
+	AvailableOnly   types.Bool    `tfsdk:"available_only"`
+}
+
+// ComputeCatalogEntry maps an instance of the catalog.
+type ComputeCatalogEntry struct {
+	ID              types.String  `tfsdk:"id"`
+	Vendor          types.String  `tfsdk:"vendor"`
+	Region          types.String  `tfsdk:"region"`
+	Accelerator     types.String  `tfsdk:"accelerator"`
+	InstanceType    types.String  `tfsdk:"instance_type"`
+	InstanceSize    types.String  `tfsdk:"instance_size"`
+	Architecture    types.String  `tfsdk:"architecture"`
+	NumAccelerators types.Int32   `tfsdk:"num_accelerators"`
+	MemoryGb        types.Int32   `tfsdk:"memory_gb"`
+	GpuMemoryGb     types.Int32   `tfsdk:"gpu_memory_gb"`
+	Status          types.String  `tfsdk:"status"`
+	Available       types.Bool    `tfsdk:"available"`
+	PricePerHour    types.Float64 `tfsdk:"price_per_hour"`
+}
@@ -2,7 +2,17 @@ package states
 
 import (
 	"github.com/sebps/terraform-provider-huggingface/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // endpointResourceState maps the resource schema data.
-type EndpointResourceState models.Endpoint
+type EndpointResourceState struct {
+	models.Endpoint
+	TagsAll                    types.List     `tfsdk:"tags_all"`
+	DesiredState               types.String   `tfsdk:"desired_state"`
+	EstimatedHourlyCostUSD     types.Float64  `tfsdk:"estimated_hourly_cost_usd"`
+	EstimatedMaxMonthlyCostUSD types.Float64  `tfsdk:"estimated_max_monthly_cost_usd"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+}
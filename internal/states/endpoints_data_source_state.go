@@ -8,6 +8,20 @@ import (
 
 // endpointsDataSourceModel maps the data source schema data.
 type EndpointsDataSourceState struct {
-	Namespace types.String      `tfsdk:"namespace"`
-	Endpoints []models.Endpoint `tfsdk:"endpoints"`
+	Namespace types.String               `tfsdk:"namespace"`
+	Filter    *EndpointsDataSourceFilter `tfsdk:"filter"`
+	Endpoints []models.Endpoint          `tfsdk:"endpoints"`
+	IDs       []types.String             `tfsdk:"ids"`
+	ByName    map[string]models.Endpoint `tfsdk:"by_name"`
+}
+
+// EndpointsDataSourceFilter maps the optional filter narrowing down the listed endpoints.
+type EndpointsDataSourceFilter struct {
+	Tag              types.String `tfsdk:"tag"`
+	States           types.Set    `tfsdk:"states"`
+	Vendor           types.String `tfsdk:"vendor"`
+	Region           types.String `tfsdk:"region"`
+	Accelerator      types.String `tfsdk:"accelerator"`
+	NameRegex        types.String `tfsdk:"name_regex"`
+	RepositoryPrefix types.String `tfsdk:"repository_prefix"`
 }
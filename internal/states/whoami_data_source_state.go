@@ -0,0 +1,31 @@
+package states
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WhoAmIDataSourceState maps the whoami data source schema data.
+type WhoAmIDataSourceState struct {
+	ID                     types.String   `tfsdk:"id"`
+	Name                   types.String   `tfsdk:"name"`
+	Fullname               types.String   `tfsdk:"fullname"`
+	Type                   types.String   `tfsdk:"type"`
+	Orgs                   []WhoAmIOrg    `tfsdk:"orgs"`
+	TokenName              types.String   `tfsdk:"token_name"`
+	TokenRole              types.String   `tfsdk:"token_role"`
+	TokenGlobalPermissions []types.String `tfsdk:"token_global_permissions"`
+	TokenScopes            []WhoAmIScope  `tfsdk:"token_scopes"`
+}
+
+// WhoAmIOrg maps an organization of the token owner.
+type WhoAmIOrg struct {
+	Name types.String `tfsdk:"name"`
+	Role types.String `tfsdk:"role"`
+}
+
+// WhoAmIScope maps the fine-grained permissions of the token on a user or an organization.
+type WhoAmIScope struct {
+	EntityType  types.String   `tfsdk:"entity_type"`
+	EntityName  types.String   `tfsdk:"entity_name"`
+	Permissions []types.String `tfsdk:"permissions"`
+}
@@ -0,0 +1,83 @@
+package testserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+)
+
+// Vendors is the hardware catalog served by the fake API.
+var Vendors = []api.Vendor{
+	{
+		Name:   "aws",
+		Status: api.StatusAvailable,
+		Regions: []api.Region{
+			{Name: "us-east-1", Label: "N. Virginia", Status: api.StatusAvailable},
+			{Name: "eu-west-1", Label: "Ireland", Status: api.StatusAvailable},
+		},
+	},
+	{
+		Name:   "azure",
+		Status: api.StatusAvailable,
+		Regions: []api.Region{
+			{Name: "eastus", Label: "Virginia", Status: api.StatusAvailable},
+		},
+	},
+}
+
+// Computes is the list of instances served by the fake API, across every
+// vendor and region of Vendors.
+var Computes = []api.Compute{
+	newCompute("aws", "us-east-1", "cpu", "intel-icl", "x4", 0, 8, 0, api.StatusAvailable, 0.134),
+	newCompute("aws", "us-east-1", "gpu", "nvidia-t4", "x1", 1, 14, 16, api.StatusAvailable, 0.5),
+	newCompute("aws", "us-east-1", "gpu", "nvidia-l4", "x1", 1, 30, 24, api.StatusAvailable, 0.8),
+	newCompute("aws", "us-east-1", "gpu", "nvidia-a10g", "x1", 1, 30, 24, api.StatusAvailable, 1.0),
+	newCompute("aws", "eu-west-1", "cpu", "intel-icl", "x4", 0, 8, 0, api.StatusAvailable, 0.134),
+	newCompute("aws", "eu-west-1", "gpu", "nvidia-l4", "x1", 1, 30, 24, api.StatusAvailable, 0.8),
+	newCompute("aws", "eu-west-1", "gpu", "nvidia-a100", "x1", 1, 140, 80, api.StatusUnavailable, 4.0),
+	newCompute("azure", "eastus", "cpu", "intel-xeon", "x4", 0, 8, 0, api.StatusAvailable, 0.16),
+}
+
+func newCompute(
+	vendor, region, accelerator, instanceType, instanceSize string,
+	numAccelerators, memoryGb, gpuMemoryGb int,
+	status string,
+	pricePerHour float64,
+) api.Compute {
+	return api.Compute{
+		ID:              fmt.Sprintf("%s-%s-%s-%s-%s", vendor, region, accelerator, instanceType, instanceSize),
+		Vendor:          vendor,
+		Region:          region,
+		Accelerator:     accelerator,
+		InstanceType:    instanceType,
+		InstanceSize:    instanceSize,
+		Architecture:    instanceType,
+		NumAccelerators: numAccelerators,
+		MemoryGb:        memoryGb,
+		GpuMemoryGb:     gpuMemoryGb,
+		Status:          status,
+		PricePerHour:    pricePerHour,
+	}
+}
+
+func (s *Server) listVendors(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"vendors": Vendors})
+}
+
+func (s *Server) listComputes(w http.ResponseWriter, r *http.Request) {
+	vendor, region := r.PathValue("vendor"), r.PathValue("region")
+
+	computes := []api.Compute{}
+	for _, compute := range Computes {
+		if compute.Vendor == vendor && compute.Region == region {
+			computes = append(computes, compute)
+		}
+	}
+	if len(computes) == 0 {
+		writeError(w, http.StatusNotFound, "Region "+region+" not found for vendor "+vendor)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": computes})
+}
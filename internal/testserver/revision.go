@@ -0,0 +1,39 @@
+package testserver
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// SetModelRevision moves a branch or tag of a model repository to a commit,
+// simulating a push to the Hub.
+func (s *Server) SetModelRevision(repository, revision, sha string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.revisions[repository+"@"+revision] = sha
+}
+
+// modelRevision answers the Hub model revision API. Revisions not set with
+// SetModelRevision resolve to a commit derived from the repository and
+// revision names.
+func (s *Server) modelRevision(w http.ResponseWriter, r *http.Request) {
+	repository, revision, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/models/"), "/revision/")
+	if !ok || repository == "" || revision == "" {
+		writeError(w, http.StatusNotFound, "Revision not found")
+		return
+	}
+
+	s.mu.Lock()
+	sha, ok := s.revisions[repository+"@"+revision]
+	s.mu.Unlock()
+
+	if !ok {
+		sum := sha1.Sum([]byte(repository + "@" + revision))
+		sha = hex.EncodeToString(sum[:])
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": repository, "sha": sha})
+}
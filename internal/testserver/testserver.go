@@ -0,0 +1,598 @@
+// Package testserver provides an in-memory fake of the Inference Endpoints
+// API, and of the Hub whoami and model revision APIs, so acceptance tests can
+// run without a Hugging Face account.
+package testserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// user is reported as the creator and last updater of every endpoint.
+var user = huggingface.EndpointAccount{
+	ID:   "000000000000000000000000",
+	Name: "terraform-acc",
+}
+
+// Server is an httptest server modelling the endpoint lifecycle in memory.
+//
+// State transitions are driven by reads rather than wall clock time: every
+// GET on an endpoint in a transitional state moves it one step forward, so
+// pending -> initializing -> running after creation, updating -> running
+// after an update, and a deleted endpoint is returned once more before it
+// starts answering 404. FailEndpoint makes a deployment end in failed instead
+// of running, DelayRollout makes an update keep reporting the previous state
+// for a few reads and FailReads makes reads answer an error.
+type Server struct {
+	*httptest.Server
+
+	// Token, when set, is the only bearer token accepted by the server. Any
+	// non-empty token is accepted otherwise.
+	Token string
+	// ForbiddenNamespace, when set, is a namespace whose endpoints answer 403,
+	// like for a token without access to it.
+	ForbiddenNamespace string
+
+	mu        sync.Mutex
+	endpoints map[string]*huggingface.EndpointWithStatus
+	// deleting holds endpoints being torn down, they are still returned by
+	// the next GET and answer 404 afterwards.
+	deleting map[string]bool
+	// revisions maps repository@revision to the commit set by SetModelRevision.
+	revisions map[string]string
+	// registryPasswords holds the last registry password sent for each
+	// endpoint, never returned by the API.
+	registryPasswords map[string]string
+	// failures holds the error messages set by FailEndpoint.
+	failures map[string]string
+	// rolloutDelays holds the number of reads set by DelayRollout.
+	rolloutDelays map[string]int
+	// delayedRollouts holds the updated endpoints whose rollout has not
+	// started yet, with the number of reads left before it does.
+	delayedRollouts map[string]int
+	// readFailures holds the status code and number of failing reads set by
+	// FailReads.
+	readFailures map[string]readFailure
+}
+
+// readFailure is an error answered by the next reads of an endpoint.
+type readFailure struct {
+	statusCode int
+	count      int
+}
+
+// New starts a fake Inference Endpoints API. Callers must Close it.
+func New() *Server {
+	s := &Server{
+		endpoints:         map[string]*huggingface.EndpointWithStatus{},
+		deleting:          map[string]bool{},
+		revisions:         map[string]string{},
+		registryPasswords: map[string]string{},
+		failures:          map[string]string{},
+		rolloutDelays:     map[string]int{},
+		delayedRollouts:   map[string]int{},
+		readFailures:      map[string]readFailure{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/endpoint/{namespace}", s.listEndpoints)
+	mux.HandleFunc("POST /v2/endpoint/{namespace}", s.createEndpoint)
+	mux.HandleFunc("GET /v2/endpoint/{namespace}/{name}", s.getEndpoint)
+	mux.HandleFunc("PUT /v2/endpoint/{namespace}/{name}", s.updateEndpoint)
+	mux.HandleFunc("DELETE /v2/endpoint/{namespace}/{name}", s.deleteEndpoint)
+	mux.HandleFunc("POST /v2/endpoint/{namespace}/{name}/pause", s.transition(huggingface.StatePaused))
+	mux.HandleFunc("POST /v2/endpoint/{namespace}/{name}/resume", s.transition(huggingface.StateInitializing))
+	mux.HandleFunc("POST /v2/endpoint/{namespace}/{name}/scale-to-zero", s.transition(huggingface.StateScaledToZero))
+	mux.HandleFunc("GET /v2/provider", s.listVendors)
+	mux.HandleFunc("GET /v2/provider/{vendor}/regions/{region}/compute", s.listComputes)
+	mux.HandleFunc("GET /api/whoami-v2", s.whoami)
+	mux.HandleFunc("GET /api/models/", s.modelRevision)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+
+	return s
+}
+
+// SeedEndpoint stores a running endpoint, bypassing the create lifecycle.
+func (s *Server) SeedEndpoint(namespace string, endpoint huggingface.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.storeRegistryPassword(key(namespace, endpoint.Name), &endpoint.Model.Image)
+	stored := newEndpointWithStatus(endpoint)
+	setState(stored, huggingface.StateRunning)
+	s.endpoints[key(namespace, endpoint.Name)] = stored
+}
+
+// SetState forces the state of an endpoint, simulating a change made outside
+// of Terraform. The error message is only kept for failed states.
+func (s *Server) SetState(namespace, name string, state huggingface.EndpointState, errorMessage string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint, ok := s.endpoints[key(namespace, name)]
+	if !ok {
+		return false
+	}
+
+	setState(endpoint, state)
+	if state == huggingface.StateFailed || state == huggingface.StateUpdateFailed {
+		endpoint.Status.ErrorMessage = &errorMessage
+	}
+
+	return true
+}
+
+// FailEndpoint makes the next deployment of an endpoint, created or updated
+// from now on, land in the failed state with errorMessage instead of running.
+func (s *Server) FailEndpoint(namespace, name, errorMessage string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[key(namespace, name)] = errorMessage
+}
+
+// DelayRollout makes the next update of an endpoint keep reporting its
+// previous state for the given number of reads before it starts updating, like
+// the API does while a rollout is being scheduled.
+func (s *Server) DelayRollout(namespace, name string, reads int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rolloutDelays[key(namespace, name)] = reads
+}
+
+// FailReads makes the next count reads of an endpoint answer statusCode,
+// simulating transient API errors.
+func (s *Server) FailReads(namespace, name string, statusCode, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.readFailures[key(namespace, name)] = readFailure{statusCode: statusCode, count: count}
+}
+
+// RemoveEndpoint deletes an endpoint immediately, simulating a deletion made
+// outside of Terraform.
+func (s *Server) RemoveEndpoint(namespace, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.endpoints, key(namespace, name))
+	delete(s.deleting, key(namespace, name))
+	delete(s.registryPasswords, key(namespace, name))
+	delete(s.failures, key(namespace, name))
+	delete(s.rolloutDelays, key(namespace, name))
+	delete(s.delayedRollouts, key(namespace, name))
+	delete(s.readFailures, key(namespace, name))
+}
+
+// RegistryPassword returns the last custom image registry password sent for
+// an endpoint, which the API never returns.
+func (s *Server) RegistryPassword(namespace, name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	password, ok := s.registryPasswords[key(namespace, name)]
+
+	return password, ok
+}
+
+// Endpoint returns a copy of a stored endpoint.
+func (s *Server) Endpoint(namespace, name string) (huggingface.EndpointWithStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(namespace, name)
+	endpoint, ok := s.endpoints[k]
+	if !ok || s.deleting[k] {
+		return huggingface.EndpointWithStatus{}, false
+	}
+
+	return *endpoint, true
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || (s.Token != "" && token != s.Token) {
+			writeError(w, http.StatusUnauthorized, "Invalid credentials in Authorization header")
+			return
+		}
+		if s.ForbiddenNamespace != "" && strings.HasPrefix(r.URL.Path+"/", "/v2/endpoint/"+s.ForbiddenNamespace+"/") {
+			writeError(w, http.StatusForbidden, "Token has no access to namespace "+s.ForbiddenNamespace)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) listEndpoints(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	namespace := r.PathValue("namespace")
+	var tags []string
+	if r.URL.Query().Has("tags") {
+		tags = strings.Split(r.URL.Query().Get("tags"), ",")
+	}
+
+	items := []huggingface.EndpointWithStatus{}
+	for _, k := range sortedKeys(s.endpoints) {
+		endpoint := s.endpoints[k]
+		if !strings.HasPrefix(k, namespace+"/") || s.deleting[k] {
+			continue
+		}
+		if tags != nil && !slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(endpoint.Tags, tag) }) {
+			continue
+		}
+		items = append(items, *endpoint)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (s *Server) createEndpoint(w http.ResponseWriter, r *http.Request) {
+	var endpoint huggingface.Endpoint
+	if err := json.NewDecoder(r.Body).Decode(&endpoint); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if endpoint.Name == "" {
+		writeError(w, http.StatusBadRequest, "Endpoint name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(r.PathValue("namespace"), endpoint.Name)
+	if _, ok := s.endpoints[k]; ok {
+		writeError(w, http.StatusConflict, "Endpoint "+k+" already exists")
+		return
+	}
+
+	s.storeRegistryPassword(k, &endpoint.Model.Image)
+	stored := newEndpointWithStatus(endpoint)
+	setState(stored, huggingface.StatePending)
+	s.endpoints[k] = stored
+
+	writeJSON(w, http.StatusOK, stored)
+}
+
+func (s *Server) getEndpoint(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(r.PathValue("namespace"), r.PathValue("name"))
+	if failure, failing := s.readFailures[k]; failing {
+		if failure.count--; failure.count > 0 {
+			s.readFailures[k] = failure
+		} else {
+			delete(s.readFailures, k)
+		}
+		writeError(w, failure.statusCode, "Endpoint "+k+" unavailable")
+		return
+	}
+
+	endpoint, ok := s.endpoints[k]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Endpoint "+k+" not found")
+		return
+	}
+
+	response := *endpoint
+	if reads, delayed := s.delayedRollouts[k]; delayed {
+		// the rollout starts after the delayed reads
+		if reads--; reads > 0 {
+			s.delayedRollouts[k] = reads
+		} else {
+			delete(s.delayedRollouts, k)
+			setState(endpoint, huggingface.StateUpdating)
+		}
+	} else if s.deleting[k] {
+		delete(s.endpoints, k)
+		delete(s.deleting, k)
+	} else {
+		s.advance(k, endpoint)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) updateEndpoint(w http.ResponseWriter, r *http.Request) {
+	var update huggingface.EndpointUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	k := key(r.PathValue("namespace"), r.PathValue("name"))
+	if update.Model != nil && update.Model.Image != nil {
+		s.storeRegistryPassword(k, update.Model.Image)
+	}
+	applyUpdate(endpoint, update)
+	if endpoint.Status.State != huggingface.StatePaused {
+		if reads := s.rolloutDelays[k]; reads > 0 {
+			delete(s.rolloutDelays, k)
+			s.delayedRollouts[k] = reads
+		} else {
+			setState(endpoint, huggingface.StateUpdating)
+		}
+	}
+	endpoint.Status.UpdatedAt = time.Now().UTC()
+
+	writeJSON(w, http.StatusOK, endpoint)
+}
+
+func (s *Server) deleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lookup(w, r); !ok {
+		return
+	}
+
+	s.deleting[key(r.PathValue("namespace"), r.PathValue("name"))] = true
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) transition(state huggingface.EndpointState) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		endpoint, ok := s.lookup(w, r)
+		if !ok {
+			return
+		}
+
+		setState(endpoint, state)
+		writeJSON(w, http.StatusOK, endpoint)
+	}
+}
+
+// lookup returns the endpoint addressed by the request path or writes a 404.
+// Callers must hold the lock.
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) (*huggingface.EndpointWithStatus, bool) {
+	k := key(r.PathValue("namespace"), r.PathValue("name"))
+	endpoint, ok := s.endpoints[k]
+	if !ok || s.deleting[k] {
+		writeError(w, http.StatusNotFound, "Endpoint "+k+" not found")
+		return nil, false
+	}
+
+	return endpoint, true
+}
+
+func newEndpointWithStatus(endpoint huggingface.Endpoint) *huggingface.EndpointWithStatus {
+	now := time.Now().UTC()
+	computeID := fmt.Sprintf("%s-%s-%s-%s-%s",
+		endpoint.Provider.Vendor,
+		endpoint.Provider.Region,
+		endpoint.Compute.Accelerator,
+		endpoint.Compute.InstanceType,
+		endpoint.Compute.InstanceSize,
+	)
+	endpoint.Compute.ID = &computeID
+	if endpoint.Compute.Scaling.Metric == nil {
+		// The API scales on hardware usage unless told otherwise
+		metric := huggingface.ScalingMetricHardwareUsage
+		endpoint.Compute.Scaling.Metric = &metric
+	}
+	if endpoint.Tags == nil {
+		endpoint.Tags = []string{}
+	}
+	endpoint.Model.Secrets = hideSecrets(endpoint.Model.Secrets)
+
+	return &huggingface.EndpointWithStatus{
+		Name:                 endpoint.Name,
+		Type:                 endpoint.Type,
+		Provider:             endpoint.Provider,
+		Compute:              endpoint.Compute,
+		Model:                endpoint.Model,
+		Tags:                 endpoint.Tags,
+		CacheHttpResponses:   endpoint.CacheHttpResponses,
+		ExperimentalFeatures: endpoint.ExperimentalFeatures,
+		PrivateService:       endpoint.PrivateService,
+		Route:                endpoint.Route,
+		Status: huggingface.EndpointStatus{
+			CreatedAt: now,
+			CreatedBy: user,
+			UpdatedAt: now,
+			UpdatedBy: user,
+		},
+	}
+}
+
+func applyUpdate(endpoint *huggingface.EndpointWithStatus, update huggingface.EndpointUpdate) {
+	if update.Type != nil {
+		endpoint.Type = *update.Type
+	}
+	if update.Tags != nil {
+		endpoint.Tags = update.Tags
+	}
+	if update.ExperimentalFeatures != nil {
+		endpoint.ExperimentalFeatures = update.ExperimentalFeatures
+	}
+	if update.Route != nil {
+		endpoint.Route = update.Route
+	}
+
+	if compute := update.Compute; compute != nil {
+		if compute.Accelerator != nil {
+			endpoint.Compute.Accelerator = *compute.Accelerator
+		}
+		if compute.InstanceType != nil {
+			endpoint.Compute.InstanceType = *compute.InstanceType
+		}
+		if compute.InstanceSize != nil {
+			endpoint.Compute.InstanceSize = *compute.InstanceSize
+		}
+		if scaling := compute.Scaling; scaling != nil {
+			if scaling.MinReplica != nil {
+				endpoint.Compute.Scaling.MinReplica = *scaling.MinReplica
+			}
+			if scaling.MaxReplica != nil {
+				endpoint.Compute.Scaling.MaxReplica = *scaling.MaxReplica
+			}
+			if scaling.Measure != nil {
+				endpoint.Compute.Scaling.Measure = scaling.Measure
+			}
+			if scaling.Metric != nil {
+				endpoint.Compute.Scaling.Metric = scaling.Metric
+			}
+			if scaling.ScaleToZeroTimeout != nil {
+				endpoint.Compute.Scaling.ScaleToZeroTimeout = scaling.ScaleToZeroTimeout
+			}
+			if scaling.Threshold != nil {
+				endpoint.Compute.Scaling.Threshold = scaling.Threshold
+			}
+		}
+	}
+
+	if model := update.Model; model != nil {
+		if model.Repository != nil {
+			endpoint.Model.Repository = *model.Repository
+		}
+		if model.Framework != nil {
+			endpoint.Model.Framework = *model.Framework
+		}
+		if model.Task != nil {
+			endpoint.Model.Task = *model.Task
+		}
+		if model.Image != nil {
+			endpoint.Model.Image = *model.Image
+		}
+		if model.Revision != nil {
+			endpoint.Model.Revision = model.Revision
+		}
+		if model.Env != nil {
+			endpoint.Model.Env = model.Env
+		}
+		if model.Secrets != nil {
+			endpoint.Model.Secrets = hideSecrets(model.Secrets)
+		}
+		if model.Args != nil {
+			endpoint.Model.Args = model.Args
+		}
+		if model.Command != nil {
+			endpoint.Model.Command = model.Command
+		}
+	}
+}
+
+// advance moves an endpoint one step forward in its lifecycle, into the
+// failed state set by FailEndpoint if any. Callers must hold the lock.
+func (s *Server) advance(k string, endpoint *huggingface.EndpointWithStatus) {
+	switch endpoint.Status.State {
+	case huggingface.StatePending:
+		setState(endpoint, huggingface.StateInitializing)
+	case huggingface.StateInitializing, huggingface.StateUpdating:
+		errorMessage, failing := s.failures[k]
+		if !failing {
+			setState(endpoint, huggingface.StateRunning)
+			return
+		}
+
+		delete(s.failures, k)
+		state := huggingface.StateFailed
+		if endpoint.Status.State == huggingface.StateUpdating {
+			state = huggingface.StateUpdateFailed
+		}
+		setState(endpoint, state)
+		endpoint.Status.ErrorMessage = &errorMessage
+	}
+}
+
+func setState(endpoint *huggingface.EndpointWithStatus, state huggingface.EndpointState) {
+	endpoint.Status.State = state
+	endpoint.Status.Message = "Endpoint is " + string(state)
+	endpoint.Status.ErrorMessage = nil
+
+	switch state {
+	case huggingface.StateRunning:
+		url := fmt.Sprintf("https://%s.us-east-1.aws.endpoints.huggingface.cloud", endpoint.Name)
+		endpoint.Status.URL = &url
+		endpoint.Status.TargetReplica = max(endpoint.Compute.Scaling.MinReplica, 1)
+		endpoint.Status.ReadyReplica = endpoint.Status.TargetReplica
+	case huggingface.StatePaused, huggingface.StateScaledToZero:
+		endpoint.Status.URL = nil
+		endpoint.Status.TargetReplica = 0
+		endpoint.Status.ReadyReplica = 0
+	default:
+		endpoint.Status.TargetReplica = max(endpoint.Compute.Scaling.MinReplica, 1)
+		endpoint.Status.ReadyReplica = 0
+	}
+}
+
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func sortedKeys(endpoints map[string]*huggingface.EndpointWithStatus) []string {
+	keys := make([]string, 0, len(endpoints))
+	for k := range endpoints {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{"error": message})
+}
+
+// storeRegistryPassword keeps the registry password of a custom image aside
+// and removes it from the image, like the API does.
+func (s *Server) storeRegistryPassword(k string, image *huggingface.EndpointModelImage) {
+	if image.Custom == nil || image.Custom.Credentials == nil || image.Custom.Credentials.Password == nil {
+		return
+	}
+
+	s.registryPasswords[k] = *image.Custom.Credentials.Password
+
+	// copy rather than alter the image of the caller
+	custom, credentials := *image.Custom, *image.Custom.Credentials
+	credentials.Password = nil
+	custom.Credentials = &credentials
+	image.Custom = &custom
+}
+
+// hideSecrets keeps the names of the secrets only, as the API never returns
+// their values.
+func hideSecrets(secrets map[string]*string) map[string]*string {
+	if secrets == nil {
+		return nil
+	}
+
+	hidden := make(map[string]*string, len(secrets))
+	for name := range secrets {
+		hidden[name] = nil
+	}
+
+	return hidden
+}
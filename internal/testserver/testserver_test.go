@@ -0,0 +1,332 @@
+package testserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+)
+
+const namespace = "terraform-acc"
+
+func newTestClient(t *testing.T, s *Server, token string) *huggingface.Client {
+	t.Helper()
+
+	client, err := huggingface.NewClient(&s.URL, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func testEndpoint(name string) huggingface.Endpoint {
+	return huggingface.Endpoint{
+		Name: name,
+		Type: huggingface.TypeProtected,
+		Provider: huggingface.EndpointProvider{
+			Vendor: "aws",
+			Region: "us-east-1",
+		},
+		Compute: huggingface.EndpointCompute{
+			Accelerator:  huggingface.AcceleratorCPU,
+			InstanceType: "intel-icl",
+			InstanceSize: "x4",
+			Scaling: huggingface.EndpointScaling{
+				MinReplica: 0,
+				MaxReplica: 1,
+			},
+		},
+		Model: huggingface.EndpointModel{
+			Repository: "openai-community/gpt2",
+			Framework:  huggingface.FrameworkPytorch,
+			Task:       "text-generation",
+			Image: huggingface.EndpointModelImage{
+				HuggingFace: &huggingface.HuggingFaceImage{},
+			},
+		},
+	}
+}
+
+func expectState(t *testing.T, client *huggingface.Client, name string, expected huggingface.EndpointState) *huggingface.EndpointWithStatus {
+	t.Helper()
+
+	endpoint, err := client.GetEndpoint(namespace, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.Status.State != expected {
+		t.Fatalf("expected state %q, got %q", expected, endpoint.Status.State)
+	}
+
+	return endpoint
+}
+
+func TestLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(t, s, "hf_test")
+
+	created, err := client.CreateEndpoint(namespace, testEndpoint("lifecycle"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Status.State != huggingface.StatePending || created.Compute.ID == nil {
+		t.Fatalf("unexpected created endpoint: %+v", created)
+	}
+
+	if _, err := client.CreateEndpoint(namespace, testEndpoint("lifecycle")); api.StatusCode(err) != 409 {
+		t.Fatalf("expected a conflict on duplicate create, got %v", err)
+	}
+
+	expectState(t, client, "lifecycle", huggingface.StatePending)
+	expectState(t, client, "lifecycle", huggingface.StateInitializing)
+	running := expectState(t, client, "lifecycle", huggingface.StateRunning)
+	if running.Status.URL == nil {
+		t.Fatal("expected a running endpoint to expose its URL")
+	}
+
+	maxReplica := 2
+	updated, err := client.UpdateEndpoint(namespace, "lifecycle", huggingface.EndpointUpdate{
+		Compute: &huggingface.EndpointComputeUpdate{
+			Scaling: &huggingface.EndpointScalingUpdate{MaxReplica: &maxReplica},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Compute.Scaling.MaxReplica != 2 || updated.Status.State != huggingface.StateUpdating {
+		t.Fatalf("unexpected updated endpoint: %+v", updated)
+	}
+	expectState(t, client, "lifecycle", huggingface.StateUpdating)
+	expectState(t, client, "lifecycle", huggingface.StateRunning)
+
+	if err := client.PauseEndpoint(namespace, "lifecycle"); err != nil {
+		t.Fatal(err)
+	}
+	expectState(t, client, "lifecycle", huggingface.StatePaused)
+
+	if err := client.ResumeEndpoint(namespace, "lifecycle"); err != nil {
+		t.Fatal(err)
+	}
+	expectState(t, client, "lifecycle", huggingface.StateInitializing)
+	expectState(t, client, "lifecycle", huggingface.StateRunning)
+
+	if err := client.ScaleEndpointToZero(namespace, "lifecycle"); err != nil {
+		t.Fatal(err)
+	}
+	expectState(t, client, "lifecycle", huggingface.StateScaledToZero)
+
+	if err := client.DeleteEndpoint(namespace, "lifecycle"); err != nil {
+		t.Fatal(err)
+	}
+	expectState(t, client, "lifecycle", huggingface.StateScaledToZero)
+	if _, err := client.GetEndpoint(namespace, "lifecycle"); !api.IsNotFound(err) {
+		t.Fatalf("expected a deleted endpoint to be not found, got %v", err)
+	}
+	if err := client.DeleteEndpoint(namespace, "lifecycle"); !api.IsNotFound(err) {
+		t.Fatalf("expected deleting a missing endpoint to be not found, got %v", err)
+	}
+}
+
+func TestFailEndpoint(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(t, s, "hf_test")
+
+	s.FailEndpoint(namespace, "failing", "CUDA out of memory")
+	if _, err := client.CreateEndpoint(namespace, testEndpoint("failing")); err != nil {
+		t.Fatal(err)
+	}
+
+	expectState(t, client, "failing", huggingface.StatePending)
+	expectState(t, client, "failing", huggingface.StateInitializing)
+	failed := expectState(t, client, "failing", huggingface.StateFailed)
+	if failed.Status.ErrorMessage == nil || *failed.Status.ErrorMessage != "CUDA out of memory" {
+		t.Fatalf("expected the error message of the failure, got %v", failed.Status.ErrorMessage)
+	}
+
+	// The failure only applies once
+	if _, err := client.UpdateEndpoint(namespace, "failing", huggingface.EndpointUpdate{}); err != nil {
+		t.Fatal(err)
+	}
+	expectState(t, client, "failing", huggingface.StateUpdating)
+	expectState(t, client, "failing", huggingface.StateRunning)
+}
+
+func TestDelayRollout(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(t, s, "hf_test")
+
+	s.SeedEndpoint(namespace, testEndpoint("delayed"))
+	s.DelayRollout(namespace, "delayed", 1)
+
+	updated, err := client.UpdateEndpoint(namespace, "delayed", huggingface.EndpointUpdate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.State != huggingface.StateRunning {
+		t.Fatalf("expected the update to report the previous state, got %q", updated.Status.State)
+	}
+
+	expectState(t, client, "delayed", huggingface.StateRunning)
+	expectState(t, client, "delayed", huggingface.StateUpdating)
+	expectState(t, client, "delayed", huggingface.StateRunning)
+}
+
+func TestFailReads(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(t, s, "hf_test")
+
+	s.SeedEndpoint(namespace, testEndpoint("flaky"))
+	s.FailReads(namespace, "flaky", 503, 2)
+
+	for range 2 {
+		if _, err := client.GetEndpoint(namespace, "flaky"); api.StatusCode(err) != 503 {
+			t.Fatalf("expected a service unavailable error, got %v", err)
+		}
+	}
+	expectState(t, client, "flaky", huggingface.StateRunning)
+}
+
+func TestListEndpoints(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(t, s, "hf_test")
+
+	tagged := testEndpoint("tagged")
+	tagged.Tags = []string{"team-a"}
+	s.SeedEndpoint(namespace, tagged)
+	s.SeedEndpoint(namespace, testEndpoint("untagged"))
+	s.SeedEndpoint("other-namespace", testEndpoint("elsewhere"))
+
+	endpoints, err := client.ListEndpoints(namespace, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 2 || endpoints[0].Name != "tagged" || endpoints[1].Name != "untagged" {
+		t.Fatalf("unexpected endpoints: %+v", endpoints)
+	}
+
+	tag := "team-a"
+	endpoints, err = client.ListEndpoints(namespace, &tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 1 || endpoints[0].Name != "tagged" {
+		t.Fatalf("unexpected tagged endpoints: %+v", endpoints)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.Token = "hf_expected"
+
+	if _, err := newTestClient(t, s, "hf_other").ListEndpoints(namespace, nil); !api.IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+	if _, err := newTestClient(t, s, "hf_expected").ListEndpoints(namespace, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	s.ForbiddenNamespace = namespace
+	if _, err := newTestClient(t, s, "hf_expected").GetEndpoint(namespace, "demo"); api.StatusCode(err) != 403 {
+		t.Fatalf("expected a forbidden error, got %v", err)
+	}
+	if _, err := newTestClient(t, s, "hf_expected").ListEndpoints("other-namespace", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWhoAmI(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.Token = "hf_expected"
+
+	if _, err := api.GetWhoAmI(context.Background(), newTestClient(t, s, "hf_other"), s.URL); !api.IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+
+	whoami, err := api.GetWhoAmI(context.Background(), newTestClient(t, s, "hf_expected"), s.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if whoami.Name != Identity.Name || whoami.Role() != api.TokenRoleFineGrained || !whoami.HasEndpointsAccess() {
+		t.Errorf("unexpected identity %+v", whoami)
+	}
+}
+
+func TestModelRevision(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	ctx := context.Background()
+	client := newTestClient(t, s, "hf_test")
+
+	main, err := api.ResolveRevision(ctx, client, s.URL, "openai-community/gpt2", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !api.IsCommitSHA(main) {
+		t.Fatalf("expected a commit SHA, got %q", main)
+	}
+
+	pushed := strings.Repeat("a", 40)
+	s.SetModelRevision("openai-community/gpt2", "main", pushed)
+	if moved, err := api.ResolveRevision(ctx, client, s.URL, "openai-community/gpt2", "main"); err != nil || moved != pushed {
+		t.Fatalf("expected the pushed commit %s, got %q (%v)", pushed, moved, err)
+	}
+}
+
+func TestRegistryPassword(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(t, s, "hf_test")
+
+	password := "s3cr3t"
+	endpoint := testEndpoint("registry")
+	endpoint.Model.Image = huggingface.EndpointModelImage{
+		Custom: &huggingface.CustomImage{
+			URL:  "registry.example.com/model:latest",
+			Port: 8080,
+			Credentials: &huggingface.Credentials{
+				Username: "robot",
+				Password: &password,
+			},
+		},
+	}
+
+	created, err := client.CreateEndpoint(namespace, endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credentials := created.Model.Image.Custom.Credentials; credentials.Username != "robot" || credentials.Password != nil {
+		t.Fatalf("expected the registry password to be hidden, got %+v", credentials)
+	}
+	if stored, ok := s.RegistryPassword(namespace, "registry"); !ok || stored != password {
+		t.Fatalf("expected the registry password %q to be stored, got %q", password, stored)
+	}
+
+	rotated := "r0tat3d"
+	image := endpoint.Model.Image
+	image.Custom.Credentials.Password = &rotated
+	if _, err := client.UpdateEndpoint(namespace, "registry", huggingface.EndpointUpdate{
+		Model: &huggingface.EndpointModelUpdate{Image: &image},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := s.RegistryPassword(namespace, "registry"); stored != rotated {
+		t.Fatalf("expected the rotated registry password %q, got %q", rotated, stored)
+	}
+}
@@ -0,0 +1,38 @@
+package testserver
+
+import (
+	"net/http"
+
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+)
+
+// Identity is the token owner served by the fake whoami API, a fine-grained
+// token allowed to manage the endpoints of the terraform-acc organization.
+var Identity = api.WhoAmI{
+	Type:     "user",
+	Name:     "terraform-acc-user",
+	Fullname: "Terraform Acceptance Tests",
+	Orgs: []api.Org{
+		{Name: user.Name, RoleInOrg: "admin"},
+	},
+	Auth: api.Auth{
+		Type: "access_token",
+		AccessToken: &api.AccessToken{
+			DisplayName: "terraform-acc",
+			Role:        api.TokenRoleFineGrained,
+			FineGrained: &api.FineGrained{
+				Global: []string{"inference.serverless.write"},
+				Scoped: []api.TokenScope{
+					{
+						Entity:      api.TokenEntity{Type: "org", Name: user.Name},
+						Permissions: []string{"inference.endpoints.write", "inference.endpoints.infer.write"},
+					},
+				},
+			},
+		},
+	},
+}
+
+func (s *Server) whoami(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, Identity)
+}
@@ -0,0 +1,156 @@
+package transformers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// attributeTyped is implemented by the structs of internal/models, which map
+// a nested object of the schemas.
+type attributeTyped interface {
+	AttributeTypes() map[string]attr.Type
+}
+
+// isKnown reports whether value is set, neither null nor unknown.
+func isKnown(value attr.Value) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// decodeObject decodes object into the model struct target, reporting false
+// when the object is null or unknown, as there is nothing to send for it, or
+// when it does not match target.
+func decodeObject(ctx context.Context, object types.Object, attributePath path.Path, target attributeTyped, diags *diag.Diagnostics) bool {
+	if !isKnown(object) {
+		return false
+	}
+
+	d := withPath(attributePath, object.As(ctx, target, basetypes.ObjectAsOptions{}))
+	diags.Append(d...)
+
+	return !d.HasError()
+}
+
+// objectValue converts the model struct value to an object, null when value
+// is nil.
+func objectValue[T attributeTyped](ctx context.Context, value *T, attributePath path.Path, diags *diag.Diagnostics) types.Object {
+	var model T
+	if value == nil {
+		return types.ObjectNull(model.AttributeTypes())
+	}
+
+	object, d := types.ObjectValueFrom(ctx, model.AttributeTypes(), *value)
+	diags.Append(withPath(attributePath, d)...)
+
+	return object
+}
+
+// withPath makes the diagnostics raised for the value at attributePath target
+// it, their own paths being relative to that value.
+func withPath(attributePath path.Path, diags diag.Diagnostics) diag.Diagnostics {
+	output := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		diagnosticPath := attributePath
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			diagnosticPath = joinPath(attributePath, withPath.Path())
+		}
+		output = append(output, diag.WithPath(diagnosticPath, d))
+	}
+
+	return output
+}
+
+// joinPath returns the path of relative under attributePath.
+func joinPath(attributePath, relative path.Path) path.Path {
+	for _, step := range relative.Steps() {
+		switch step := step.(type) {
+		case path.PathStepAttributeName:
+			attributePath = attributePath.AtName(string(step))
+		case path.PathStepElementKeyInt:
+			attributePath = attributePath.AtListIndex(int(step))
+		case path.PathStepElementKeyString:
+			attributePath = attributePath.AtMapKey(string(step))
+		case path.PathStepElementKeyValue:
+			attributePath = attributePath.AtSetValue(step.Value)
+		}
+	}
+
+	return attributePath
+}
+
+// stringPointer returns the value of a string, nil when null or unknown.
+func stringPointer(value types.String) *string {
+	return enumPointer[string](value)
+}
+
+// enumPointer returns the value of a string as the API enum T, nil when null
+// or unknown.
+func enumPointer[T ~string](value types.String) *T {
+	if !isKnown(value) {
+		return nil
+	}
+
+	output := T(value.ValueString())
+	return &output
+}
+
+// intPointer returns the value of an integer, nil when null or unknown.
+func intPointer(value types.Int32) *int {
+	if !isKnown(value) {
+		return nil
+	}
+
+	output := int(value.ValueInt32())
+	return &output
+}
+
+// intValue returns the value of an integer, fallback when null or unknown.
+func intValue(value types.Int32, fallback int) int {
+	if output := intPointer(value); output != nil {
+		return *output
+	}
+
+	return fallback
+}
+
+// float64Pointer returns the value of a float, nil when null or unknown.
+func float64Pointer(value types.Float64) *float64 {
+	if !isKnown(value) {
+		return nil
+	}
+
+	output := value.ValueFloat64()
+	return &output
+}
+
+// boolPointer returns the value of a boolean, nil when null or unknown.
+func boolPointer(value types.Bool) *bool {
+	if !isKnown(value) {
+		return nil
+	}
+
+	output := value.ValueBool()
+	return &output
+}
+
+// int32PointerValue converts an API integer to an Int32, null when nil.
+func int32PointerValue(value *int) types.Int32 {
+	if value == nil {
+		return types.Int32Null()
+	}
+
+	return types.Int32Value(int32(*value))
+}
+
+// enumPointerValue converts an API enum to a String, null when nil.
+func enumPointerValue[T ~string](value *T) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(*value))
+}
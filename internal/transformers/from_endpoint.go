@@ -0,0 +1,316 @@
+package transformers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/api"
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+)
+
+// fromEndpoint maps an API endpoint to its model. Fields the API leaves out
+// are null, or their zero value for those read as such before.
+func fromEndpoint(ctx context.Context, input *huggingface.EndpointWithStatus, diags *diag.Diagnostics) (output models.Endpoint) {
+	output.Name = types.StringValue(input.Name)
+	output.Type = types.StringValue(string(input.Type))
+	output.CacheHttpResponses = types.BoolValue(input.CacheHttpResponses != nil && *input.CacheHttpResponses)
+
+	output.CloudProvider = objectValue(ctx, &models.EndpointCloudProvider{
+		Vendor: types.StringValue(input.Provider.Vendor),
+		Region: types.StringValue(input.Provider.Region),
+	}, cloudProviderPath, diags)
+
+	output.Compute = objectValue(ctx, fromCompute(ctx, input.Compute, computePath, diags), computePath, diags)
+	output.Model = objectValue(ctx, fromModel(ctx, input.Model, modelPath, diags), modelPath, diags)
+
+	tags, d := types.ListValueFrom(ctx, types.StringType, input.Tags)
+	diags.Append(withPath(tagsPath, d)...)
+	output.Tags = tags
+
+	experimentalFeatures := models.ExperimentalFeatures{CacheHTTPResponses: types.BoolValue(false)}
+	kvRouter := models.KvRouter{Tag: types.StringValue("")}
+	if input.ExperimentalFeatures != nil {
+		experimentalFeatures.CacheHTTPResponses = types.BoolValue(input.ExperimentalFeatures.CacheHttpResponses)
+		if input.ExperimentalFeatures.KvRouter != nil {
+			kvRouter.Tag = types.StringValue(input.ExperimentalFeatures.KvRouter.Tag)
+		}
+	}
+	experimentalFeatures.KVRouter = objectValue(ctx, &kvRouter, experimentalFeaturesPath.AtName("kv_router"), diags)
+	output.ExperimentalFeatures = objectValue(ctx, &experimentalFeatures, experimentalFeaturesPath, diags)
+
+	privateService := huggingface.EndpointPrivateService{}
+	if input.PrivateService != nil {
+		privateService = *input.PrivateService
+	}
+	output.PrivateService = objectValue(ctx, &models.PrivateService{
+		AccountID: types.StringValue(privateService.AccountID),
+		Shared:    types.BoolValue(privateService.Shared),
+	}, privateServicePath, diags)
+
+	route := huggingface.RouteSpec{}
+	if input.Route != nil {
+		route = *input.Route
+	}
+	output.Route = objectValue(ctx, &models.Route{
+		Domain: types.StringValue(route.Domain),
+		Path:   types.StringValue(route.Path),
+	}, routePath, diags)
+
+	output.Status = objectValue(ctx, fromStatus(ctx, input.Status, statusPath, diags), statusPath, diags)
+
+	return output
+}
+
+func fromCompute(ctx context.Context, compute huggingface.EndpointCompute, attributePath path.Path, diags *diag.Diagnostics) *models.EndpointCompute {
+	scaling := models.EndpointComputeScaling{
+		MinReplica:         types.Int32Value(int32(compute.Scaling.MinReplica)),
+		MaxReplica:         types.Int32Value(int32(compute.Scaling.MaxReplica)),
+		Metric:             enumPointerValue(compute.Scaling.Metric),
+		ScaleToZeroTimeout: int32PointerValue(compute.Scaling.ScaleToZeroTimeout),
+		Threshold:          types.Float64PointerValue(compute.Scaling.Threshold),
+	}
+
+	var measure *models.EndpointComputeScalingMeasure
+	if compute.Scaling.Measure != nil {
+		measure = &models.EndpointComputeScalingMeasure{
+			HardwareUsage:   types.Float64PointerValue(compute.Scaling.Measure.HardwareUsage),
+			PendingRequests: types.Float64PointerValue(compute.Scaling.Measure.PendingRequests),
+		}
+	}
+	scaling.Measure = objectValue(ctx, measure, attributePath.AtName("scaling").AtName("measure"), diags)
+
+	return &models.EndpointCompute{
+		ID:           types.StringPointerValue(compute.ID),
+		Accelerator:  types.StringValue(string(compute.Accelerator)),
+		InstanceType: types.StringValue(compute.InstanceType),
+		InstanceSize: types.StringValue(compute.InstanceSize),
+		Scaling:      objectValue(ctx, &scaling, attributePath.AtName("scaling"), diags),
+	}
+}
+
+func fromModel(ctx context.Context, model huggingface.EndpointModel, attributePath path.Path, diags *diag.Diagnostics) *models.Model {
+	output := models.Model{
+		Repository: types.StringValue(model.Repository),
+		Framework:  types.StringValue(string(model.Framework)),
+		Task:       types.StringValue(string(model.Task)),
+		Revision:   types.StringPointerValue(model.Revision),
+		// only a commit SHA identifies the served commit
+		ResolvedSHA:   types.StringNull(),
+		TrackRevision: types.BoolNull(),
+		// secret values are never returned by the API
+		Secrets:          types.MapNull(types.StringType),
+		SecretsWO:        types.MapNull(types.StringType),
+		SecretsWOVersion: types.Int32Null(),
+	}
+	if model.Revision != nil && api.IsCommitSHA(*model.Revision) {
+		output.ResolvedSHA = types.StringValue(*model.Revision)
+	}
+
+	env := model.Env
+	if env == nil {
+		env = map[string]string{}
+	}
+	var d diag.Diagnostics
+	output.Env, d = types.MapValueFrom(ctx, types.StringType, env)
+	diags.Append(withPath(attributePath.AtName("env"), d)...)
+
+	output.Image = objectValue(ctx, fromImage(ctx, model.Image, attributePath.AtName("image"), diags), attributePath.AtName("image"), diags)
+
+	return &output
+}
+
+func fromImage(ctx context.Context, image huggingface.EndpointModelImage, attributePath path.Path, diags *diag.Diagnostics) *models.ModelImage {
+	var huggingFace *models.ModelImageHuggingface
+	if image.HuggingFace != nil {
+		huggingFace = &models.ModelImageHuggingface{}
+	}
+
+	var huggingFaceNeuron *models.ModelImageHuggingfaceNeuron
+	if image.HuggingFaceNeuron != nil {
+		huggingFaceNeuron = &models.ModelImageHuggingfaceNeuron{
+			BatchSize:      int32PointerValue(image.HuggingFaceNeuron.BatchSize),
+			NeuronCache:    types.StringValue(image.HuggingFaceNeuron.NeuronCache),
+			SequenceLength: int32PointerValue(image.HuggingFaceNeuron.SequenceLength),
+		}
+	}
+
+	var tgi *models.ModelImageTgi
+	if image.TGI != nil {
+		tgi = &models.ModelImageTgi{
+			HealthRoute:           types.StringPointerValue(image.TGI.HealthRoute),
+			Port:                  types.Int32Value(int32(image.TGI.Port)),
+			Url:                   types.StringValue(image.TGI.URL),
+			MaxBatchPrefillTokens: int32PointerValue(image.TGI.MaxBatchPrefillTokens),
+			MaxBatchTotalTokens:   int32PointerValue(image.TGI.MaxBatchTotalTokens),
+			MaxInputLength:        int32PointerValue(image.TGI.MaxInputLength),
+			MaxTotalTokens:        int32PointerValue(image.TGI.MaxTotalTokens),
+			DisableCustomKernels:  types.BoolValue(image.TGI.DisableCustomKernels),
+			Quantize:              enumPointerValue(image.TGI.Quantize),
+		}
+	}
+
+	var tgiNeuron *models.ModelImageTgiNeuron
+	if image.TGINeuron != nil {
+		tgiNeuron = &models.ModelImageTgiNeuron{
+			HealthRoute:           types.StringPointerValue(image.TGINeuron.HealthRoute),
+			Port:                  types.Int32Value(int32(image.TGINeuron.Port)),
+			Url:                   types.StringValue(image.TGINeuron.URL),
+			MaxBatchPrefillTokens: int32PointerValue(image.TGINeuron.MaxBatchPrefillTokens),
+			MaxBatchTotalTokens:   int32PointerValue(image.TGINeuron.MaxBatchTotalTokens),
+			MaxInputLength:        int32PointerValue(image.TGINeuron.MaxInputLength),
+			MaxTotalTokens:        int32PointerValue(image.TGINeuron.MaxTotalTokens),
+			HfAutoCastType:        enumPointerValue(image.TGINeuron.HfAutoCastType),
+			HfNumCores:            int32PointerValue(image.TGINeuron.HfNumCores),
+		}
+	}
+
+	var tei *models.ModelImageTei
+	if image.TEI != nil {
+		tei = &models.ModelImageTei{
+			HealthRoute:           types.StringPointerValue(image.TEI.HealthRoute),
+			Port:                  types.Int32Value(int32(image.TEI.Port)),
+			URL:                   types.StringValue(image.TEI.URL),
+			MaxBatchTokens:        int32PointerValue(image.TEI.MaxBatchTokens),
+			MaxConcurrentRequests: int32PointerValue(image.TEI.MaxConcurrentRequests),
+			Pooling:               enumPointerValue(image.TEI.Pooling),
+		}
+	}
+
+	var llamaCpp *models.ModelImageLlamacpp
+	if image.LlamaCpp != nil {
+		llamaCpp = &models.ModelImageLlamacpp{
+			HealthRoute: types.StringPointerValue(image.LlamaCpp.HealthRoute),
+			Port:        types.Int32Value(int32(image.LlamaCpp.Port)),
+			URL:         types.StringValue(image.LlamaCpp.URL),
+			CtxSize:     types.Int32Value(int32(image.LlamaCpp.CtxSize)),
+			Mode:        enumPointerValue(image.LlamaCpp.Mode),
+			ModelPath:   types.StringValue(image.LlamaCpp.ModelPath),
+			NGpuLayers:  types.Int32Value(int32(image.LlamaCpp.NGpuLayers)),
+			NParallel:   types.Int32Value(int32(image.LlamaCpp.NParallel)),
+			Pooling:     enumPointerValue(image.LlamaCpp.Pooling),
+			ThreadsHttp: int32PointerValue(image.LlamaCpp.ThreadsHttp),
+			Variant:     types.StringPointerValue(image.LlamaCpp.Variant),
+		}
+	}
+
+	var custom *models.ModelImageCustom
+	if image.Custom != nil {
+		var credentials *models.Credentials
+		if image.Custom.Credentials != nil {
+			// the registry password is never returned by the API
+			credentials = &models.Credentials{
+				Username:          types.StringValue(image.Custom.Credentials.Username),
+				Password:          types.StringNull(),
+				PasswordWO:        types.StringNull(),
+				PasswordWOVersion: types.Int32Null(),
+			}
+		}
+
+		custom = &models.ModelImageCustom{
+			HealthRoute: types.StringPointerValue(image.Custom.HealthRoute),
+			Port:        types.Int32Value(int32(image.Custom.Port)),
+			URL:         types.StringValue(image.Custom.URL),
+			Credentials: objectValue(ctx, credentials, attributePath.AtName("custom").AtName("credentials"), diags),
+		}
+	}
+
+	return &models.ModelImage{
+		HuggingFace:       objectValue(ctx, huggingFace, attributePath.AtName("huggingface"), diags),
+		HuggingFaceNeuron: objectValue(ctx, huggingFaceNeuron, attributePath.AtName("huggingface_neuron"), diags),
+		TGI:               objectValue(ctx, tgi, attributePath.AtName("tgi"), diags),
+		TGINeuron:         objectValue(ctx, tgiNeuron, attributePath.AtName("tgi_neuron"), diags),
+		TEI:               objectValue(ctx, tei, attributePath.AtName("tei"), diags),
+		LlamaCpp:          objectValue(ctx, llamaCpp, attributePath.AtName("llamacpp"), diags),
+		Custom:            objectValue(ctx, custom, attributePath.AtName("custom"), diags),
+	}
+}
+
+// fromDataSourceModel maps an API model to the model of the data sources,
+// leaving out the attributes only the endpoint resource manages.
+func fromDataSourceModel(ctx context.Context, model huggingface.EndpointModel, attributePath path.Path, diags *diag.Diagnostics) *models.DataSourceModel {
+	resourceModel := fromModel(ctx, model, attributePath, diags)
+	imagePath := attributePath.AtName("image")
+	customPath := imagePath.AtName("custom")
+
+	var resourceImage models.ModelImage
+	decodeObject(ctx, resourceModel.Image, imagePath, &resourceImage, diags)
+
+	// only the registry username is kept from the custom image credentials
+	var custom *models.DataSourceModelImageCustom
+	var resourceCustom models.ModelImageCustom
+	if decodeObject(ctx, resourceImage.Custom, customPath, &resourceCustom, diags) {
+		var credentials *models.DataSourceCredentials
+		var resourceCredentials models.Credentials
+		if decodeObject(ctx, resourceCustom.Credentials, customPath.AtName("credentials"), &resourceCredentials, diags) {
+			credentials = &models.DataSourceCredentials{
+				Username: resourceCredentials.Username,
+			}
+		}
+
+		custom = &models.DataSourceModelImageCustom{
+			HealthRoute: resourceCustom.HealthRoute,
+			Port:        resourceCustom.Port,
+			URL:         resourceCustom.URL,
+			Credentials: objectValue(ctx, credentials, customPath.AtName("credentials"), diags),
+		}
+	}
+
+	image := models.DataSourceModelImage{
+		HuggingFace:       resourceImage.HuggingFace,
+		HuggingFaceNeuron: resourceImage.HuggingFaceNeuron,
+		TGI:               resourceImage.TGI,
+		TGINeuron:         resourceImage.TGINeuron,
+		TEI:               resourceImage.TEI,
+		LlamaCpp:          resourceImage.LlamaCpp,
+		Custom:            objectValue(ctx, custom, customPath, diags),
+	}
+
+	return &models.DataSourceModel{
+		Repository:  resourceModel.Repository,
+		Revision:    resourceModel.Revision,
+		ResolvedSHA: resourceModel.ResolvedSHA,
+		Framework:   resourceModel.Framework,
+		Task:        resourceModel.Task,
+		Image:       objectValue(ctx, &image, imagePath, diags),
+		Env:         resourceModel.Env,
+	}
+}
+
+func fromStatus(ctx context.Context, status huggingface.EndpointStatus, attributePath path.Path, diags *diag.Diagnostics) *models.Status {
+	output := models.Status{
+		CreatedAt:     types.StringValue(status.CreatedAt.String()),
+		UpdatedAt:     types.StringValue(status.UpdatedAt.String()),
+		State:         types.StringValue(string(status.State)),
+		Message:       types.StringValue(status.Message),
+		ReadyReplica:  types.Int32Value(int32(status.ReadyReplica)),
+		TargetReplica: types.Int32Value(int32(status.TargetReplica)),
+		ErrorMessage:  types.StringValue(""),
+		Url:           types.StringValue(""),
+	}
+	if status.ErrorMessage != nil {
+		output.ErrorMessage = types.StringValue(*status.ErrorMessage)
+	}
+	if status.URL != nil {
+		output.Url = types.StringValue(*status.URL)
+	}
+
+	output.CreatedBy = objectValue(ctx, &models.User{
+		Id:   types.StringValue(status.CreatedBy.ID),
+		Name: types.StringValue(status.CreatedBy.Name),
+	}, attributePath.AtName("created_by"), diags)
+	output.UpdatedBy = objectValue(ctx, &models.User{
+		Id:   types.StringValue(status.UpdatedBy.ID),
+		Name: types.StringValue(status.UpdatedBy.Name),
+	}, attributePath.AtName("updated_by"), diags)
+
+	private := models.Private{ServiceName: types.StringValue("")}
+	if status.Private != nil && status.Private.ServiceName != nil {
+		private.ServiceName = types.StringValue(*status.Private.ServiceName)
+	}
+	output.Private = objectValue(ctx, &private, attributePath.AtName("private"), diags)
+
+	return &output
+}
@@ -0,0 +1,316 @@
+package transformers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+)
+
+// defaultTask is the task of an endpoint whose plan does not set one.
+const defaultTask = huggingface.EndpointTask("text-generation")
+
+// toEndpoint maps the plan of an endpoint to the API endpoint to create.
+// Values null or unknown in the plan are left out.
+func toEndpoint(ctx context.Context, input *models.Endpoint, diags *diag.Diagnostics) huggingface.Endpoint {
+	output := huggingface.Endpoint{
+		Name:               input.Name.ValueString(),
+		Type:               huggingface.EndpointType(input.Type.ValueString()),
+		Model:              huggingface.EndpointModel{Task: defaultTask},
+		CacheHttpResponses: boolPointer(input.CacheHttpResponses),
+	}
+
+	var cloudProvider models.EndpointCloudProvider
+	if decodeObject(ctx, input.CloudProvider, cloudProviderPath, &cloudProvider, diags) {
+		output.Provider = huggingface.EndpointProvider{
+			Vendor: cloudProvider.Vendor.ValueString(),
+			Region: cloudProvider.Region.ValueString(),
+		}
+	}
+
+	var compute models.EndpointCompute
+	if decodeObject(ctx, input.Compute, computePath, &compute, diags) {
+		output.Compute = toCompute(ctx, compute, computePath, diags)
+	}
+
+	var model models.Model
+	if decodeObject(ctx, input.Model, modelPath, &model, diags) {
+		output.Model = toModel(ctx, model, modelPath, diags)
+	}
+
+	if isKnown(input.Tags) {
+		output.Tags = []string{}
+		diags.Append(withPath(tagsPath, input.Tags.ElementsAs(ctx, &output.Tags, false))...)
+	}
+
+	var experimentalFeatures models.ExperimentalFeatures
+	if decodeObject(ctx, input.ExperimentalFeatures, experimentalFeaturesPath, &experimentalFeatures, diags) {
+		output.ExperimentalFeatures = &huggingface.ExperimentalFeatures{
+			CacheHttpResponses: experimentalFeatures.CacheHTTPResponses.ValueBool(),
+		}
+
+		var kvRouter models.KvRouter
+		if decodeObject(ctx, experimentalFeatures.KVRouter, experimentalFeaturesPath.AtName("kv_router"), &kvRouter, diags) {
+			output.ExperimentalFeatures.KvRouter = &huggingface.KvRouter{
+				Tag: kvRouter.Tag.ValueString(),
+			}
+		}
+	}
+
+	var privateService models.PrivateService
+	if decodeObject(ctx, input.PrivateService, privateServicePath, &privateService, diags) {
+		output.PrivateService = &huggingface.EndpointPrivateService{
+			AccountID: privateService.AccountID.ValueString(),
+			Shared:    privateService.Shared.ValueBool(),
+		}
+	}
+
+	var route models.Route
+	if decodeObject(ctx, input.Route, routePath, &route, diags) {
+		output.Route = &huggingface.RouteSpec{
+			Domain: route.Domain.ValueString(),
+			Path:   route.Path.ValueString(),
+		}
+	}
+
+	return output
+}
+
+// toEndpointUpdate maps the plan of an endpoint to the update payload, made
+// of the fields of the create payload which can change in place.
+func toEndpointUpdate(ctx context.Context, input *models.Endpoint, diags *diag.Diagnostics) huggingface.EndpointUpdate {
+	endpoint := toEndpoint(ctx, input, diags)
+
+	output := huggingface.EndpointUpdate{
+		Tags:                 endpoint.Tags,
+		ExperimentalFeatures: endpoint.ExperimentalFeatures,
+		Route:                endpoint.Route,
+	}
+
+	if isKnown(input.Type) {
+		output.Type = &endpoint.Type
+	}
+
+	if isKnown(input.Compute) {
+		scaling := endpoint.Compute.Scaling
+		output.Compute = &huggingface.EndpointComputeUpdate{
+			Accelerator:  &endpoint.Compute.Accelerator,
+			InstanceType: &endpoint.Compute.InstanceType,
+			InstanceSize: &endpoint.Compute.InstanceSize,
+			Scaling: &huggingface.EndpointScalingUpdate{
+				MinReplica:         &scaling.MinReplica,
+				MaxReplica:         &scaling.MaxReplica,
+				Measure:            scaling.Measure,
+				Metric:             scaling.Metric,
+				ScaleToZeroTimeout: scaling.ScaleToZeroTimeout,
+				Threshold:          scaling.Threshold,
+			},
+		}
+	}
+
+	if isKnown(input.Model) {
+		model := endpoint.Model
+		output.Model = &huggingface.EndpointModelUpdate{
+			Repository: &model.Repository,
+			Framework:  &model.Framework,
+			Image:      &model.Image,
+			Env:        model.Env,
+			Secrets:    model.Secrets,
+			Revision:   model.Revision,
+			Task:       &model.Task,
+		}
+	}
+
+	return output
+}
+
+func toCompute(ctx context.Context, compute models.EndpointCompute, attributePath path.Path, diags *diag.Diagnostics) huggingface.EndpointCompute {
+	output := huggingface.EndpointCompute{
+		Accelerator:  huggingface.AcceleratorType(compute.Accelerator.ValueString()),
+		InstanceType: compute.InstanceType.ValueString(),
+		InstanceSize: compute.InstanceSize.ValueString(),
+	}
+
+	var scaling models.EndpointComputeScaling
+	if !decodeObject(ctx, compute.Scaling, attributePath.AtName("scaling"), &scaling, diags) {
+		return output
+	}
+
+	output.Scaling = huggingface.EndpointScaling{
+		MinReplica:         intValue(scaling.MinReplica, 0),
+		MaxReplica:         intValue(scaling.MaxReplica, 1),
+		Metric:             enumPointer[huggingface.ScalingMetric](scaling.Metric),
+		ScaleToZeroTimeout: intPointer(scaling.ScaleToZeroTimeout),
+		Threshold:          float64Pointer(scaling.Threshold),
+	}
+
+	var measure models.EndpointComputeScalingMeasure
+	if decodeObject(ctx, scaling.Measure, attributePath.AtName("scaling").AtName("measure"), &measure, diags) {
+		output.Scaling.Measure = &huggingface.ScalingMeasure{
+			HardwareUsage:   float64Pointer(measure.HardwareUsage),
+			PendingRequests: float64Pointer(measure.PendingRequests),
+		}
+	}
+
+	return output
+}
+
+func toModel(ctx context.Context, model models.Model, attributePath path.Path, diags *diag.Diagnostics) huggingface.EndpointModel {
+	output := huggingface.EndpointModel{
+		Repository: model.Repository.ValueString(),
+		Framework:  huggingface.EndpointFramework(model.Framework.ValueString()),
+		Task:       defaultTask,
+		Env:        stringMap(ctx, model.Env, attributePath.AtName("env"), diags),
+		Secrets:    secretsMap(ctx, model.Secrets, attributePath.AtName("secrets"), diags),
+		// pin the endpoint to the commit resolved at plan time
+		Revision: modelRevision(model),
+	}
+
+	if task := enumPointer[huggingface.EndpointTask](model.Task); task != nil {
+		output.Task = *task
+	}
+
+	var image models.ModelImage
+	if decodeObject(ctx, model.Image, attributePath.AtName("image"), &image, diags) {
+		output.Image = toImage(ctx, image, attributePath.AtName("image"), diags)
+	}
+
+	return output
+}
+
+func toImage(ctx context.Context, image models.ModelImage, attributePath path.Path, diags *diag.Diagnostics) (output huggingface.EndpointModelImage) {
+	var huggingFace models.ModelImageHuggingface
+	if decodeObject(ctx, image.HuggingFace, attributePath.AtName("huggingface"), &huggingFace, diags) {
+		output.HuggingFace = &huggingface.HuggingFaceImage{}
+	}
+
+	var huggingFaceNeuron models.ModelImageHuggingfaceNeuron
+	if decodeObject(ctx, image.HuggingFaceNeuron, attributePath.AtName("huggingface_neuron"), &huggingFaceNeuron, diags) {
+		output.HuggingFaceNeuron = &huggingface.HuggingFaceNeuronImage{
+			BatchSize:      intPointer(huggingFaceNeuron.BatchSize),
+			NeuronCache:    huggingFaceNeuron.NeuronCache.ValueString(),
+			SequenceLength: intPointer(huggingFaceNeuron.SequenceLength),
+		}
+	}
+
+	var tgi models.ModelImageTgi
+	if decodeObject(ctx, image.TGI, attributePath.AtName("tgi"), &tgi, diags) {
+		output.TGI = &huggingface.TGIImage{
+			HealthRoute:           stringPointer(tgi.HealthRoute),
+			Port:                  int(tgi.Port.ValueInt32()),
+			URL:                   tgi.Url.ValueString(),
+			MaxBatchPrefillTokens: intPointer(tgi.MaxBatchPrefillTokens),
+			MaxBatchTotalTokens:   intPointer(tgi.MaxBatchTotalTokens),
+			MaxInputLength:        intPointer(tgi.MaxInputLength),
+			MaxTotalTokens:        intPointer(tgi.MaxTotalTokens),
+			DisableCustomKernels:  tgi.DisableCustomKernels.ValueBool(),
+			Quantize:              enumPointer[huggingface.QuantizeType](tgi.Quantize),
+		}
+	}
+
+	var tgiNeuron models.ModelImageTgiNeuron
+	if decodeObject(ctx, image.TGINeuron, attributePath.AtName("tgi_neuron"), &tgiNeuron, diags) {
+		output.TGINeuron = &huggingface.TGINeuronImage{
+			HealthRoute:           stringPointer(tgiNeuron.HealthRoute),
+			Port:                  int(tgiNeuron.Port.ValueInt32()),
+			URL:                   tgiNeuron.Url.ValueString(),
+			MaxBatchPrefillTokens: intPointer(tgiNeuron.MaxBatchPrefillTokens),
+			MaxBatchTotalTokens:   intPointer(tgiNeuron.MaxBatchTotalTokens),
+			MaxInputLength:        intPointer(tgiNeuron.MaxInputLength),
+			MaxTotalTokens:        intPointer(tgiNeuron.MaxTotalTokens),
+			HfAutoCastType:        enumPointer[huggingface.AutoCastType](tgiNeuron.HfAutoCastType),
+			HfNumCores:            intPointer(tgiNeuron.HfNumCores),
+		}
+	}
+
+	var tei models.ModelImageTei
+	if decodeObject(ctx, image.TEI, attributePath.AtName("tei"), &tei, diags) {
+		output.TEI = &huggingface.TEIImage{
+			HealthRoute:           stringPointer(tei.HealthRoute),
+			Port:                  int(tei.Port.ValueInt32()),
+			URL:                   tei.URL.ValueString(),
+			MaxBatchTokens:        intPointer(tei.MaxBatchTokens),
+			MaxConcurrentRequests: intPointer(tei.MaxConcurrentRequests),
+			Pooling:               enumPointer[huggingface.PoolingType](tei.Pooling),
+		}
+	}
+
+	var llamaCpp models.ModelImageLlamacpp
+	if decodeObject(ctx, image.LlamaCpp, attributePath.AtName("llamacpp"), &llamaCpp, diags) {
+		output.LlamaCpp = &huggingface.LlamaCppImage{
+			HealthRoute: stringPointer(llamaCpp.HealthRoute),
+			Port:        int(llamaCpp.Port.ValueInt32()),
+			URL:         llamaCpp.URL.ValueString(),
+			CtxSize:     int(llamaCpp.CtxSize.ValueInt32()),
+			Mode:        enumPointer[huggingface.ModelMode](llamaCpp.Mode),
+			ModelPath:   llamaCpp.ModelPath.ValueString(),
+			NGpuLayers:  int(llamaCpp.NGpuLayers.ValueInt32()),
+			NParallel:   int(llamaCpp.NParallel.ValueInt32()),
+			Pooling:     enumPointer[huggingface.PoolingType](llamaCpp.Pooling),
+			ThreadsHttp: intPointer(llamaCpp.ThreadsHttp),
+			Variant:     stringPointer(llamaCpp.Variant),
+		}
+	}
+
+	var custom models.ModelImageCustom
+	if decodeObject(ctx, image.Custom, attributePath.AtName("custom"), &custom, diags) {
+		output.Custom = &huggingface.CustomImage{
+			URL:         custom.URL.ValueString(),
+			HealthRoute: stringPointer(custom.HealthRoute),
+			Port:        int(custom.Port.ValueInt32()),
+		}
+
+		var credentials models.Credentials
+		if decodeObject(ctx, custom.Credentials, attributePath.AtName("custom").AtName("credentials"), &credentials, diags) {
+			output.Custom.Credentials = &huggingface.Credentials{
+				Username: credentials.Username.ValueString(),
+				Password: stringPointer(credentials.Password),
+			}
+		}
+	}
+
+	return output
+}
+
+// stringMap returns the entries of a map of strings, nil when the map is null
+// or unknown.
+func stringMap(ctx context.Context, value types.Map, attributePath path.Path, diags *diag.Diagnostics) map[string]string {
+	if !isKnown(value) {
+		return nil
+	}
+
+	var output map[string]string
+	diags.Append(withPath(attributePath, value.ElementsAs(ctx, &output, false))...)
+
+	return output
+}
+
+// secretsMap returns the secrets of the model in the form expected by the API.
+func secretsMap(ctx context.Context, value types.Map, attributePath path.Path, diags *diag.Diagnostics) map[string]*string {
+	secrets := stringMap(ctx, value, attributePath, diags)
+	if secrets == nil {
+		return nil
+	}
+
+	output := make(map[string]*string, len(secrets))
+	for name, secret := range secrets {
+		output[name] = &secret
+	}
+
+	return output
+}
+
+// modelRevision returns the revision to send to the API, the resolved commit
+// SHA when known or the configured revision otherwise.
+func modelRevision(model models.Model) *string {
+	for _, revision := range []types.String{model.ResolvedSHA, model.Revision} {
+		if isKnown(revision) && revision.ValueString() != "" {
+			return stringPointer(revision)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package transformers
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	huggingface "github.com/sebps/huggingface-client/client"
+)
+
+// FuzzRoundTrip checks endpoints generated from the fuzzed seed round trip
+// unchanged and have conforming create and update payloads.
+func FuzzRoundTrip(f *testing.F) {
+	for seed := range uint64(64) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		endpoint := randomEndpoint(rand.New(rand.NewPCG(seed, seed)))
+
+		checkRoundTrip(t, endpoint)
+		checkUpdateConformance(t, endpoint)
+	})
+}
+
+// randomEndpoint generates an API endpoint of a random image variant with
+// its optional fields randomly set or left out.
+func randomEndpoint(r *rand.Rand) huggingface.EndpointWithStatus {
+	computeID := "aws-us-east-1-cpu-intel-icl-x4"
+	endpoint := huggingface.EndpointWithStatus{
+		Name: fmt.Sprintf("endpoint-%d", r.IntN(1000)),
+		Type: oneOf(r, huggingface.TypePublic, huggingface.TypeProtected, huggingface.TypePrivate),
+		Provider: huggingface.EndpointProvider{
+			Vendor: "aws",
+			Region: "us-east-1",
+		},
+		Compute: huggingface.EndpointCompute{
+			Accelerator:  oneOf(r, huggingface.AcceleratorCPU, huggingface.AcceleratorGPU, huggingface.AcceleratorNeuron),
+			ID:           &computeID,
+			InstanceType: "intel-icl",
+			InstanceSize: "x4",
+			Scaling: huggingface.EndpointScaling{
+				MinReplica:         r.IntN(2),
+				MaxReplica:         1 + r.IntN(4),
+				Metric:             maybe(r, oneOf(r, huggingface.ScalingMetricHardwareUsage, huggingface.ScalingMetricPendingRequests)),
+				ScaleToZeroTimeout: maybe(r, 15+r.IntN(60)),
+				Threshold:          maybe(r, float64(r.IntN(200))/2),
+			},
+		},
+		Model: huggingface.EndpointModel{
+			Repository: "openai-community/gpt2",
+			Framework:  oneOf(r, huggingface.FrameworkPytorch, huggingface.FrameworkCustom, huggingface.FrameworkLlamaCpp),
+			Task:       oneOf[huggingface.EndpointTask](r, "text-generation", "sentence-embeddings"),
+			Revision:   maybe(r, oneOf(r, "main", "607a30d783dfa663caf39e06633721c8d4cfcd7e")),
+			Image:      randomImage(r),
+		},
+		Tags: oneOf(r, nil, []string{}, []string{"gpt2", "managed-by:terraform"}),
+		Status: huggingface.EndpointStatus{
+			CreatedAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2024, 5, 2, 10, 5, 0, 0, time.UTC),
+			State:     huggingface.StateRunning,
+		},
+	}
+
+	if r.IntN(2) == 0 {
+		endpoint.Compute.Scaling.Measure = &huggingface.ScalingMeasure{
+			HardwareUsage:   maybe(r, float64(r.IntN(200))/2),
+			PendingRequests: maybe(r, float64(r.IntN(20))/2),
+		}
+	}
+	if r.IntN(2) == 0 {
+		endpoint.Model.Env = map[string]string{"MAX_CONCURRENT_REQUESTS": fmt.Sprint(r.IntN(128))}
+	}
+	endpoint.CacheHttpResponses = maybe(r, r.IntN(2) == 0)
+	if r.IntN(2) == 0 {
+		endpoint.ExperimentalFeatures = &huggingface.ExperimentalFeatures{
+			CacheHttpResponses: r.IntN(2) == 0,
+		}
+		if r.IntN(2) == 0 {
+			endpoint.ExperimentalFeatures.KvRouter = &huggingface.KvRouter{Tag: oneOf(r, "", "v1")}
+		}
+	}
+	if r.IntN(2) == 0 {
+		endpoint.PrivateService = &huggingface.EndpointPrivateService{
+			AccountID: "123456789012",
+			Shared:    r.IntN(2) == 0,
+		}
+	}
+	if r.IntN(2) == 0 {
+		endpoint.Route = &huggingface.RouteSpec{
+			Domain: "gpt2.example.com",
+			Path:   oneOf(r, "", "/v1"),
+		}
+	}
+
+	return endpoint
+}
+
+// randomImage generates a model image of a random variant.
+func randomImage(r *rand.Rand) huggingface.EndpointModelImage {
+	healthRoute := maybe(r, "/health")
+
+	switch r.IntN(7) {
+	case 0:
+		return huggingface.EndpointModelImage{HuggingFace: &huggingface.HuggingFaceImage{}}
+	case 1:
+		return huggingface.EndpointModelImage{HuggingFaceNeuron: &huggingface.HuggingFaceNeuronImage{
+			BatchSize:      maybe(r, 1+r.IntN(8)),
+			NeuronCache:    "aws-neuron/optimum-neuron-cache",
+			SequenceLength: maybe(r, 1024*(1+r.IntN(4))),
+		}}
+	case 2:
+		return huggingface.EndpointModelImage{TGI: &huggingface.TGIImage{
+			HealthRoute:           healthRoute,
+			Port:                  80,
+			URL:                   "ghcr.io/huggingface/text-generation-inference:3.0.1",
+			MaxBatchPrefillTokens: maybe(r, 1024*(1+r.IntN(8))),
+			MaxBatchTotalTokens:   maybe(r, 1024*(1+r.IntN(32))),
+			MaxInputLength:        maybe(r, 1023+r.IntN(1024)),
+			MaxTotalTokens:        maybe(r, 1024+r.IntN(1024)),
+			DisableCustomKernels:  r.IntN(2) == 0,
+			Quantize:              maybe(r, oneOf(r, huggingface.QuantizeAWQ, huggingface.QuantizeBitsAndBytes, huggingface.QuantizeEETQ, huggingface.QuantizeGPTQ)),
+		}}
+	case 3:
+		return huggingface.EndpointModelImage{TGINeuron: &huggingface.TGINeuronImage{
+			HealthRoute:           healthRoute,
+			Port:                  80,
+			URL:                   "ghcr.io/huggingface/neuronx-tgi:0.0.25",
+			MaxBatchPrefillTokens: maybe(r, 1024*(1+r.IntN(8))),
+			MaxBatchTotalTokens:   maybe(r, 1024*(1+r.IntN(32))),
+			MaxInputLength:        maybe(r, 1023+r.IntN(1024)),
+			MaxTotalTokens:        maybe(r, 1024+r.IntN(1024)),
+			HfAutoCastType:        maybe(r, oneOf(r, huggingface.AutoCastBF16, huggingface.AutoCastFP16)),
+			HfNumCores:            maybe(r, 1+r.IntN(4)),
+		}}
+	case 4:
+		return huggingface.EndpointModelImage{TEI: &huggingface.TEIImage{
+			HealthRoute:           healthRoute,
+			Port:                  80,
+			URL:                   "ghcr.io/huggingface/text-embeddings-inference:cpu-1.6",
+			MaxBatchTokens:        maybe(r, 1024*(1+r.IntN(16))),
+			MaxConcurrentRequests: maybe(r, 1+r.IntN(512)),
+			Pooling:               maybe(r, oneOf(r, huggingface.PoolingMean, huggingface.PoolingCLS, huggingface.PoolingLast, huggingface.PoolingRank)),
+		}}
+	case 5:
+		return huggingface.EndpointModelImage{LlamaCpp: &huggingface.LlamaCppImage{
+			HealthRoute: healthRoute,
+			Port:        8080,
+			URL:         "ghcr.io/ggml-org/llama.cpp:server",
+			CtxSize:     1024 * (1 + r.IntN(8)),
+			Mode:        maybe(r, oneOf(r, huggingface.ModelModeEmbeddings, huggingface.ModelModeReranking)),
+			ModelPath:   "/repository/model.gguf",
+			NGpuLayers:  r.IntN(32),
+			NParallel:   1 + r.IntN(4),
+			Pooling:     maybe(r, oneOf(r, huggingface.PoolingMean, huggingface.PoolingCLS)),
+			ThreadsHttp: maybe(r, 1+r.IntN(8)),
+			Variant:     maybe(r, "q4_k_m"),
+		}}
+	}
+
+	custom := &huggingface.CustomImage{
+		URL:         "registry.example.com/gpt2:latest",
+		HealthRoute: healthRoute,
+		Port:        8080,
+	}
+	if r.IntN(2) == 0 {
+		custom.Credentials = &huggingface.Credentials{Username: "robot"}
+	}
+
+	return huggingface.EndpointModelImage{Custom: custom}
+}
+
+// maybe returns a pointer to value, or nil half of the time.
+func maybe[T any](r *rand.Rand, value T) *T {
+	if r.IntN(2) == 0 {
+		return nil
+	}
+
+	return &value
+}
+
+// oneOf returns one of values at random.
+func oneOf[T any](r *rand.Rand, values ...T) T {
+	return values[r.IntN(len(values))]
+}
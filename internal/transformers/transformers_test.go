@@ -0,0 +1,453 @@
+package transformers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	huggingface "github.com/sebps/huggingface-client/client"
+	"github.com/sebps/terraform-provider-huggingface/internal/models"
+	"github.com/sebps/terraform-provider-huggingface/internal/states"
+)
+
+// createOnlyFields are the create payload fields the update payload does not
+// carry, as they cannot be changed in place.
+var createOnlyFields = []string{"name", "provider", "cacheHttpResponses", "privateService"}
+
+// TestRoundTrip checks every fixture survives a model -> API -> model round
+// trip unchanged, as happens when an endpoint read from the API is planned
+// again.
+func TestRoundTrip(t *testing.T) {
+	for name, fixture := range loadFixtures(t) {
+		t.Run(name, func(t *testing.T) {
+			checkRoundTrip(t, fixture)
+		})
+	}
+}
+
+// TestUpdateConformance checks the update payload of every fixture carries
+// the same values as its create payload.
+func TestUpdateConformance(t *testing.T) {
+	for name, fixture := range loadFixtures(t) {
+		t.Run(name, func(t *testing.T) {
+			checkUpdateConformance(t, fixture)
+		})
+	}
+}
+
+// TestDataSourceModel checks the data source model of every fixture carries
+// the same image as the resource model, only the resource-only credentials
+// attributes being left out.
+func TestDataSourceModel(t *testing.T) {
+	ctx := context.Background()
+
+	for name, fixture := range loadFixtures(t) {
+		t.Run(name, func(t *testing.T) {
+			endpoint, diags := FromProviderToDataSourceModel(ctx, &fixture)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			image := endpoint.Model.Attributes()["image"].(types.Object).Attributes()
+			resourceImage := fromFixture(t, fixture).Model.Attributes()["image"].(types.Object).Attributes()
+			for attribute, expected := range resourceImage {
+				if attribute != "custom" && !image[attribute].Equal(expected) {
+					t.Errorf("expected image.%s %s, got %s", attribute, expected, image[attribute])
+				}
+			}
+
+			custom := image["custom"].(types.Object)
+			if fixture.Model.Image.Custom == nil {
+				if !custom.IsNull() {
+					t.Errorf("expected a null custom image, got %s", custom)
+				}
+				return
+			}
+			if url := custom.Attributes()["url"]; !url.Equal(types.StringValue(fixture.Model.Image.Custom.URL)) {
+				t.Errorf("expected the custom image URL %q, got %s", fixture.Model.Image.Custom.URL, url)
+			}
+		})
+	}
+}
+
+// TestUnknownValues checks an unknown value anywhere in the plan is sent to
+// the API as if it was null, as computed attributes are unknown at plan time.
+func TestUnknownValues(t *testing.T) {
+	ctx := context.Background()
+
+	for name, fixture := range loadFixtures(t) {
+		t.Run(name, func(t *testing.T) {
+			endpoint := fromFixture(t, fixture)
+
+			for _, attributePath := range attributePaths(ctx, endpoint) {
+				withNull := replaceAt(ctx, endpoint, attributePath, func(typ attr.Type) attr.Value {
+					return valueOf(ctx, t, typ, tftypes.NewValue(typ.TerraformType(ctx), nil))
+				})
+				withUnknown := replaceAt(ctx, endpoint, attributePath, func(typ attr.Type) attr.Value {
+					return valueOf(ctx, t, typ, tftypes.NewValue(typ.TerraformType(ctx), tftypes.UnknownValue))
+				})
+
+				null := states.EndpointResourceState{Endpoint: withNull}
+				unknown := states.EndpointResourceState{Endpoint: withUnknown}
+
+				if expected, got := toJSON(t, createPayload(t, &null)), toJSON(t, createPayload(t, &unknown)); expected != got {
+					t.Errorf("%s: unknown create payload\n%s\ndiffers from null create payload\n%s", strings.Join(attributePath, "."), got, expected)
+				}
+				if expected, got := toJSON(t, updatePayload(t, &null)), toJSON(t, updatePayload(t, &unknown)); expected != got {
+					t.Errorf("%s: unknown update payload\n%s\ndiffers from null update payload\n%s", strings.Join(attributePath, "."), got, expected)
+				}
+			}
+		})
+	}
+}
+
+// TestInvalidPlan checks a plan object not matching its model struct is
+// reported on the path of the object.
+func TestInvalidPlan(t *testing.T) {
+	ctx := context.Background()
+	endpoint := fromFixture(t, loadFixtures(t)["all_optional"])
+	invalid := types.ObjectValueMust(
+		map[string]attr.Type{"unexpected": types.StringType},
+		map[string]attr.Value{"unexpected": types.StringValue("value")},
+	)
+
+	for name, test := range map[string]struct {
+		path []string
+		want path.Path
+	}{
+		"model":           {path: []string{"model"}, want: path.Root("model")},
+		"scaling":         {path: []string{"compute", "scaling"}, want: path.Root("compute").AtName("scaling")},
+		"tgi":             {path: []string{"model", "image", "tgi"}, want: path.Root("model").AtName("image").AtName("tgi")},
+		"private_service": {path: []string{"private_service"}, want: path.Root("private_service")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			plan := states.EndpointResourceState{
+				Endpoint: replaceAt(ctx, endpoint, test.path, func(attr.Type) attr.Value { return invalid }),
+			}
+
+			for payload, diags := range map[string]diag.Diagnostics{
+				"create": second(FromModelToProvider(ctx, &plan)),
+				"update": second(FromPlanToEndpointUpdate(ctx, &plan)),
+			} {
+				if !diags.HasError() {
+					t.Fatalf("%s: expected an error", payload)
+				}
+				for _, d := range diags.Errors() {
+					withPath, ok := d.(diag.DiagnosticWithPath)
+					if !ok || !withPath.Path().Equal(test.want) {
+						t.Errorf("%s: expected an error at %s, got %v", payload, test.want, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+// checkRoundTrip converts an API endpoint to its model, sends the model back
+// as a create payload and checks the endpoint returned for it converts to the
+// same model.
+func checkRoundTrip(t *testing.T, fixture huggingface.EndpointWithStatus) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	expected := fromFixture(t, fixture)
+	plan := states.EndpointResourceState{Endpoint: expected}
+	created := createPayload(t, &plan)
+
+	got, diags := FromProviderToModel(ctx, withStatus(created, fixture))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	for _, difference := range diffEndpoints(expected, got) {
+		t.Error(difference)
+	}
+}
+
+// checkUpdateConformance checks every field of the update payload of an
+// endpoint matches the create payload, and only create-only fields are
+// missing from it.
+func checkUpdateConformance(t *testing.T, fixture huggingface.EndpointWithStatus) {
+	t.Helper()
+
+	plan := states.EndpointResourceState{Endpoint: fromFixture(t, fixture)}
+	create := toMap(t, createPayload(t, &plan))
+	update := toMap(t, updatePayload(t, &plan))
+
+	for field, value := range update {
+		if !reflect.DeepEqual(create[field], value) {
+			t.Errorf("%s: update payload %v differs from create payload %v", field, value, create[field])
+		}
+	}
+	for field := range create {
+		if _, ok := update[field]; !ok && !slices.Contains(createOnlyFields, field) {
+			t.Errorf("%s: missing from the update payload", field)
+		}
+	}
+}
+
+// loadFixtures reads the API endpoints of testdata, keyed by file name.
+func loadFixtures(t *testing.T) map[string]huggingface.EndpointWithStatus {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no fixtures found in testdata")
+	}
+
+	fixtures := make(map[string]huggingface.EndpointWithStatus, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var fixture huggingface.EndpointWithStatus
+		if err := json.Unmarshal(content, &fixture); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		fixtures[strings.TrimSuffix(filepath.Base(file), ".json")] = fixture
+	}
+
+	return fixtures
+}
+
+// fromFixture converts an API endpoint to its model.
+func fromFixture(t *testing.T, fixture huggingface.EndpointWithStatus) models.Endpoint {
+	t.Helper()
+
+	endpoint, diags := FromProviderToModel(context.Background(), &fixture)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return endpoint
+}
+
+// createPayload returns the create payload of plan.
+func createPayload(t *testing.T, plan *states.EndpointResourceState) huggingface.Endpoint {
+	t.Helper()
+
+	endpoint, diags := FromModelToProvider(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return endpoint
+}
+
+// updatePayload returns the update payload of plan.
+func updatePayload(t *testing.T, plan *states.EndpointResourceState) huggingface.EndpointUpdate {
+	t.Helper()
+
+	update, diags := FromPlanToEndpointUpdate(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return update
+}
+
+// withStatus returns the endpoint the API answers a create payload with,
+// using the server side fields of fixture.
+func withStatus(endpoint huggingface.Endpoint, fixture huggingface.EndpointWithStatus) *huggingface.EndpointWithStatus {
+	endpoint.Compute.ID = fixture.Compute.ID
+
+	return &huggingface.EndpointWithStatus{
+		Name:                 endpoint.Name,
+		Type:                 endpoint.Type,
+		Provider:             endpoint.Provider,
+		Compute:              endpoint.Compute,
+		Model:                endpoint.Model,
+		Tags:                 endpoint.Tags,
+		CacheHttpResponses:   endpoint.CacheHttpResponses,
+		ExperimentalFeatures: endpoint.ExperimentalFeatures,
+		PrivateService:       endpoint.PrivateService,
+		Route:                endpoint.Route,
+		Status:               fixture.Status,
+	}
+}
+
+// diffEndpoints describes the attributes differing between two endpoints.
+func diffEndpoints(expected, got models.Endpoint) []string {
+	var differences []string
+	for name, values := range map[string][2]attr.Value{
+		"name":                  {expected.Name, got.Name},
+		"type":                  {expected.Type, got.Type},
+		"cloud_provider":        {expected.CloudProvider, got.CloudProvider},
+		"compute":               {expected.Compute, got.Compute},
+		"model":                 {expected.Model, got.Model},
+		"tags":                  {expected.Tags, got.Tags},
+		"cache_http_responses":  {expected.CacheHttpResponses, got.CacheHttpResponses},
+		"experimental_features": {expected.ExperimentalFeatures, got.ExperimentalFeatures},
+		"private_service":       {expected.PrivateService, got.PrivateService},
+		"route":                 {expected.Route, got.Route},
+		"status":                {expected.Status, got.Status},
+	} {
+		differences = append(differences, diffValues(name, values[0], values[1])...)
+	}
+	slices.Sort(differences)
+
+	return differences
+}
+
+// diffValues describes the attributes differing between two values, down to
+// the nested attributes of objects.
+func diffValues(name string, expected, got attr.Value) []string {
+	expectedObject, ok := expected.(types.Object)
+	gotObject, isObject := got.(types.Object)
+	if !ok || !isObject || expectedObject.IsNull() || expectedObject.IsUnknown() || gotObject.IsNull() || gotObject.IsUnknown() {
+		if expected.Equal(got) {
+			return nil
+		}
+		return []string{name + ": expected " + expected.String() + ", got " + got.String()}
+	}
+
+	var differences []string
+	for attribute, value := range expectedObject.Attributes() {
+		gotValue, ok := gotObject.Attributes()[attribute]
+		if !ok {
+			differences = append(differences, name+"."+attribute+": missing")
+			continue
+		}
+		differences = append(differences, diffValues(name+"."+attribute, value, gotValue)...)
+	}
+
+	return differences
+}
+
+// planObjects are the nested objects of the endpoint model sent to the API.
+var planObjects = []string{"cloud_provider", "compute", "model", "experimental_features", "private_service", "route"}
+
+// attributePaths lists the paths of the nested objects sent to the API and
+// of all their attributes.
+func attributePaths(ctx context.Context, endpoint models.Endpoint) [][]string {
+	var paths [][]string
+
+	var walk func(path []string, value attr.Value)
+	walk = func(path []string, value attr.Value) {
+		paths = append(paths, path)
+
+		object, ok := value.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			return
+		}
+		names := make([]string, 0, len(object.Attributes()))
+		for name := range object.Attributes() {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			walk(append(slices.Clone(path), name), object.Attributes()[name])
+		}
+	}
+
+	for _, name := range planObjects {
+		walk([]string{name}, *rootObject(&endpoint, name))
+	}
+
+	return paths
+}
+
+// replaceAt returns a copy of endpoint with the value at path replaced by the
+// value returned by replacement for its type. The objects holding it take the
+// type of the replacement.
+func replaceAt(ctx context.Context, endpoint models.Endpoint, path []string, replacement func(attr.Type) attr.Value) models.Endpoint {
+	var replace func(value attr.Value, path []string) attr.Value
+	replace = func(value attr.Value, path []string) attr.Value {
+		if len(path) == 0 {
+			return replacement(value.Type(ctx))
+		}
+
+		object := value.(types.Object)
+		attributes := make(map[string]attr.Value, len(object.Attributes()))
+		for name, attribute := range object.Attributes() {
+			attributes[name] = attribute
+		}
+		attributes[path[0]] = replace(attributes[path[0]], path[1:])
+
+		attributeTypes := make(map[string]attr.Type, len(attributes))
+		for name, attribute := range attributes {
+			attributeTypes[name] = attribute.Type(ctx)
+		}
+
+		return types.ObjectValueMust(attributeTypes, attributes)
+	}
+
+	root := rootObject(&endpoint, path[0])
+	*root = replace(*root, path[1:]).(types.Object)
+
+	return endpoint
+}
+
+// rootObject returns the nested object of the endpoint model named name.
+func rootObject(endpoint *models.Endpoint, name string) *types.Object {
+	switch name {
+	case "cloud_provider":
+		return &endpoint.CloudProvider
+	case "compute":
+		return &endpoint.Compute
+	case "model":
+		return &endpoint.Model
+	case "experimental_features":
+		return &endpoint.ExperimentalFeatures
+	case "private_service":
+		return &endpoint.PrivateService
+	case "route":
+		return &endpoint.Route
+	}
+
+	panic("unexpected endpoint object " + name)
+}
+
+// valueOf converts a terraform value to a framework value of type typ.
+func valueOf(ctx context.Context, t *testing.T, typ attr.Type, value tftypes.Value) attr.Value {
+	t.Helper()
+
+	converted, err := typ.ValueFromTerraform(ctx, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return converted
+}
+
+func second[T any](_ T, diags diag.Diagnostics) diag.Diagnostics {
+	return diags
+}
+
+func toJSON(t *testing.T, value any) string {
+	t.Helper()
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func toMap(t *testing.T, value any) map[string]any {
+	t.Helper()
+
+	var output map[string]any
+	if err := json.Unmarshal([]byte(toJSON(t, value)), &output); err != nil {
+		t.Fatal(err)
+	}
+
+	return output
+}
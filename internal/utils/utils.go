@@ -21,7 +21,7 @@ func GenerateStringID(namespace, name string) types.String {
 }
 
 func ParseStringID(ID types.String) (namespace, name string, err error) {
-	chunks := strings.Split(ID.String(), "/")
+	chunks := strings.Split(ID.ValueString(), "/")
 	if len(chunks) != 2 {
 		err = errors.New("wrong ID")
 		return
@@ -29,3 +29,21 @@ func ParseStringID(ID types.String) (namespace, name string, err error) {
 
 	return chunks[0], chunks[1], nil
 }
+
+// ParseImportID splits an import ID of the form namespace/name. A bare name
+// is resolved against defaultNamespace.
+func ParseImportID(ID, defaultNamespace string) (namespace, name string, err error) {
+	chunks := strings.Split(ID, "/")
+	switch {
+	case len(chunks) == 2 && chunks[0] != "" && chunks[1] != "":
+		return chunks[0], chunks[1], nil
+	case len(chunks) == 1 && chunks[0] != "" && defaultNamespace != "":
+		return defaultNamespace, chunks[0], nil
+	case len(chunks) == 1 && chunks[0] != "":
+		err = errors.New("ID has no namespace and no default_namespace is configured on the provider")
+	default:
+		err = errors.New("wrong ID")
+	}
+
+	return
+}